@@ -0,0 +1,97 @@
+package checks
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	aliasesMu sync.RWMutex
+	aliases   = map[string][]string{}
+	patterns  = map[string]string{}
+)
+
+//RegisterAlias registers the rule expanding to the rules of the tag, e.g.
+//RegisterAlias("port", "required,expect:80;443"). Aliases may use other aliases.
+//Panics if the name is empty, contains the colon or the comma, is the name of the known
+//rule or the alias uses itself through other aliases. Aliases must be registered
+//before checks of the tags using them
+func RegisterAlias(name string, rules string) {
+	if name == "" || strings.ContainsAny(name, ":,") || parseRule(name).known() {
+		panic(fmt.Sprintf("checks: RegisterAlias of invalid name %q", name))
+	}
+	expanded := splitTag(rules)
+	aliasesMu.Lock()
+	defer aliasesMu.Unlock()
+	if cycle := aliasCycle(name, expanded, []string{name}); cycle != nil {
+		panic("checks: alias cycle: " + strings.Join(cycle, " -> "))
+	}
+	aliases[name] = expanded
+	resetRules()
+}
+
+//RegisterPattern registers the regular expression referenced by @name in re and match
+//rules, e.g. re:@semver. Panics if the regular expression does not compile
+func RegisterPattern(name string, expr string) {
+	regexp.MustCompile(expr)
+	aliasesMu.Lock()
+	defer aliasesMu.Unlock()
+	patterns[name] = expr
+}
+
+func lookupPattern(name string) (string, bool) {
+	aliasesMu.RLock()
+	defer aliasesMu.RUnlock()
+	expr, ok := patterns[name]
+	return expr, ok
+}
+
+//aliasCycle returns the path from the alias to itself through rules of the alias if any
+func aliasCycle(name string, rules []string, path []string) []string {
+	for _, r := range rules {
+		if r == name {
+			return append(path, r)
+		}
+		if expanded, ok := aliases[r]; ok {
+			if cycle := aliasCycle(name, expanded, append(path[:len(path):len(path)], r)); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+//expandAliases replaces aliases with their rules
+func expandAliases(rules []string) []string {
+	aliasesMu.RLock()
+	defer aliasesMu.RUnlock()
+	if len(aliases) == 0 {
+		return rules
+	}
+	return expand(rules)
+}
+
+func expand(rules []string) []string {
+	result := make([]string, 0, len(rules))
+	for _, r := range rules {
+		if expanded, ok := aliases[r]; ok {
+			result = append(result, expand(expanded)...)
+			continue
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+//resetRules clears rules of tags parsed before the alias is registered
+func resetRules() {
+	rulesMu.Lock()
+	rulesCache = map[string][]rule{}
+	rulesMu.Unlock()
+	normalizableMu.Lock()
+	normalizableTypes = map[reflect.Type]bool{}
+	normalizableMu.Unlock()
+}
@@ -0,0 +1,89 @@
+package checks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterAlias(t *testing.T) {
+	RegisterAlias("test-port", "required,expect:80;443")
+	RegisterAlias("test-ports", "minlen:1,dive,test-port")
+	assert.Equal(t, []string{"required", "expect:80;443", "x"}, expandAliases([]string{"test-port", "x"}))
+	assert.Equal(t, []string{"minlen:1", "dive", "required", "expect:80;443"}, expandAliases([]string{"test-ports"}))
+
+	assert.PanicsWithValue(t, `checks: RegisterAlias of invalid name "required"`, func() {
+		RegisterAlias("required", "expect:1")
+	})
+	assert.PanicsWithValue(t, `checks: RegisterAlias of invalid name "a:b"`, func() {
+		RegisterAlias("a:b", "required")
+	})
+	assert.PanicsWithValue(t, "checks: alias cycle: test-self -> test-self", func() {
+		RegisterAlias("test-self", "required,test-self")
+	})
+	RegisterAlias("test-a", "required")
+	RegisterAlias("test-b", "test-a")
+	assert.PanicsWithValue(t, "checks: alias cycle: test-a -> test-b -> test-a", func() {
+		RegisterAlias("test-a", "test-b")
+	})
+	assert.Equal(t, []string{"required"}, expandAliases([]string{"test-b"}))
+}
+
+func TestCheckAlias(t *testing.T) {
+	type testAlias struct {
+		Port  int    `check:"test-alias-port"`
+		Ports []int  `check:"test-alias-ports"`
+		Name  string `check:"test-alias-name"`
+	}
+	v := &testAlias{Port: 8080, Ports: []int{80, 0}, Name: " Name "}
+	errs := New(ModeAll, ErrorAll).Check(v)
+	assert.Len(t, errs, 3)
+	assert.EqualError(t, errs[0], "unknown check: test-alias-port")
+	assert.EqualError(t, errs[1], "unknown check: test-alias-ports")
+	assert.EqualError(t, errs[2], "unknown check: test-alias-name")
+
+	//tags parsed before the alias is registered are parsed again
+	RegisterAlias("test-alias-port", "required,expect:80;443")
+	RegisterAlias("test-alias-ports", "minlen:1,dive,test-alias-port")
+	RegisterAlias("test-alias-name", "trim,lower")
+	errs = New(ModeAll, ErrorAll).Check(v)
+	assert.Len(t, errs, 3)
+	assert.EqualError(t, errs[0], "unexpected value: Port 8080")
+	assert.EqualError(t, errs[1], "value required: Ports[1]")
+	assert.EqualError(t, errs[2], "unexpected value: Ports[1] 0")
+	assert.Equal(t, "name", v.Name)
+}
+
+func TestRegisterPattern(t *testing.T) {
+	type testPattern struct {
+		Version string  `check:"match:@test-semver"`
+		Search  string  `check:"re:@test-semver"`
+		Tag     *string `check:"!match:@test-semver"`
+		Missing string  `check:"re:@test-missing"`
+		Mention string  `check:"match:@@[a-z]+"`
+	}
+	RegisterPattern("test-semver", `[0-9]+\.[0-9]+\.[0-9]+`)
+	assert.Panics(t, func() {
+		RegisterPattern("test-bad", "[a-z")
+	})
+
+	tag := "1.0.0"
+	errs := New(ModeAll, ErrorAll).Check(&testPattern{Version: "v1.0.0", Search: "v1.0.0", Tag: &tag, Mention: "@user"})
+	assert.Len(t, errs, 3)
+	assert.EqualError(t, errs[0], "no matches: Version match:@test-semver")
+	assert.EqualError(t, errs[1], "unexpected match: Tag !match:@test-semver")
+	assert.EqualError(t, errs[2], "pattern not found: Missing re:@test-missing")
+
+	//@@ escapes the leading @ of the regular expression
+	errs = New(ModeAll, ErrorAll).Check(&testPattern{Version: "1.0.0", Search: "1.0.0", Mention: "user"})
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[1], "no matches: Mention match:@@[a-z]+")
+	assert.NoError(t, MatchRule("Mention", "re:@@[a-z]", "@user"))
+
+	assert.EqualError(t, Compile(reflect.TypeOf(testPattern{})),
+		"invalid check tags of checks.testPattern:\n\tpattern not found: Missing re:@test-missing")
+	assert.NoError(t, MatchRule("Version", "match:@test-semver", "1.2.3"))
+	assert.EqualError(t, MatchRule("Version", "!re:@test-semver", "v1.2.3"), "unexpected match: Version !re:@test-semver")
+	assert.EqualError(t, MatchRule("Version", "re:@test-missing", "1"), "pattern not found: Version re:@test-missing")
+}
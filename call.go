@@ -0,0 +1,282 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//Kinds of the name parameter of call methods
+const (
+	nameNone = iota
+	nameString
+	nameField
+)
+
+type (
+	//Field describes the checked field for methods of the call check
+	//accepting it instead of the name of the field
+	Field struct {
+		Name string
+		Path string
+	}
+
+	//signature describes parameters and results of the method of the call check:
+	//[ctx context.Context] [name string | field checks.Field] [value T] [args...] error | []error.
+	//Methods of the checked value do not accept the value
+	signature struct {
+		ctx    bool
+		name   int
+		value  bool
+		errors bool
+		params []reflect.Type
+	}
+
+	//callRule is the parsed argument of the call rule: Name or Name(arg1,arg2)
+	callRule struct {
+		name string
+		args []string
+	}
+)
+
+var (
+	contextInterface = reflect.TypeOf((*context.Context)(nil)).Elem()
+	fieldStruct      = reflect.TypeOf(Field{})
+	errorSlice       = reflect.TypeOf([]error(nil))
+
+	funcsMu sync.RWMutex
+	funcs   = map[string]reflect.Value{}
+)
+
+//RegisterFunc registers the function referenced by the name in the call check.
+//The function accepts [ctx context.Context] [name string | field checks.Field] value [args...]
+//and returns error or []error. Methods of the struct and of the value take precedence
+func RegisterFunc(name string, fn interface{}) {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		panic(fmt.Sprintf("checks: RegisterFunc of non-function %T", fn))
+	}
+	funcsMu.Lock()
+	defer funcsMu.Unlock()
+	funcs[name] = v
+}
+
+func lookupFunc(name string) (reflect.Value, bool) {
+	funcsMu.RLock()
+	defer funcsMu.RUnlock()
+	fn, ok := funcs[name]
+	return fn, ok
+}
+
+func parseCall(arg string) (callRule, bool) {
+	idx := strings.Index(arg, "(")
+	if idx < 0 {
+		return callRule{name: arg}, arg != ""
+	}
+	if idx == 0 || !strings.HasSuffix(arg, ")") {
+		return callRule{}, false
+	}
+	c := callRule{name: arg[:idx]}
+	if args := strings.TrimSpace(arg[idx+1 : len(arg)-1]); args != "" {
+		for _, a := range strings.Split(args, ",") {
+			c.args = append(c.args, strings.TrimSpace(a))
+		}
+	}
+	return c, true
+}
+
+//argType reports whether arguments of the call rule can be converted to the type
+func argType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+//parseSignature returns the signature of the method of the call check accepting nargs arguments
+//of the rule. The receiver of the method type is skipped if skip is 1.
+//Methods of the owner and registered functions accept the value
+func parseSignature(method reflect.Type, skip int, value reflect.Type, owner bool, nargs int) (signature, bool) {
+	var sig signature
+	if method.IsVariadic() || method.NumOut() != 1 {
+		return sig, false
+	}
+	switch out := method.Out(0); {
+	case out == errorSlice:
+		sig.errors = true
+	case !out.Implements(errorInterface):
+		return sig, false
+	}
+
+	in := make([]reflect.Type, 0, method.NumIn())
+	for i := skip; i < method.NumIn(); i++ {
+		in = append(in, method.In(i))
+	}
+	if len(in) > 0 && in[0] == contextInterface {
+		sig.ctx = true
+		in = in[1:]
+	}
+	if len(in) < nargs {
+		return sig, false
+	}
+	if nargs > 0 {
+		sig.params, in = in[len(in)-nargs:], in[:len(in)-nargs]
+	}
+	for _, t := range sig.params {
+		if !argType(t) {
+			return sig, false
+		}
+	}
+	if owner {
+		if len(in) == 0 || !value.AssignableTo(in[len(in)-1]) {
+			return sig, false
+		}
+		sig.value = true
+		in = in[:len(in)-1]
+	}
+	switch {
+	case len(in) == 0:
+	case len(in) > 1:
+		return sig, false
+	case in[0] == fieldStruct:
+		sig.name = nameField
+	case reflect.TypeOf("").AssignableTo(in[0]):
+		sig.name = nameString
+	default:
+		return sig, false
+	}
+	return sig, true
+}
+
+//values converts arguments of the call rule to the parameters of the method
+func (s signature) values(args []string) ([]reflect.Value, bool) {
+	values := make([]reflect.Value, 0, len(args))
+	for i, arg := range args {
+		t := s.params[i]
+		v := reflect.New(t).Elem()
+		var err error
+		switch t.Kind() {
+		case reflect.String:
+			v.SetString(arg)
+		case reflect.Bool:
+			var b bool
+			b, err = strconv.ParseBool(arg)
+			v.SetBool(b)
+		case reflect.Float32, reflect.Float64:
+			var f float64
+			f, err = strconv.ParseFloat(arg, t.Bits())
+			v.SetFloat(f)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			var n uint64
+			n, err = strconv.ParseUint(arg, 10, t.Bits())
+			v.SetUint(n)
+		default:
+			var n int64
+			n, err = strconv.ParseInt(arg, 10, t.Bits())
+			v.SetInt(n)
+		}
+		if err != nil {
+			return nil, false
+		}
+		values = append(values, v)
+	}
+	return values, true
+}
+
+//args returns arguments of the method
+func (s signature) args(ctx context.Context, field Field, value reflect.Value, params []reflect.Value) []reflect.Value {
+	args := make([]reflect.Value, 0, 3+len(params))
+	if s.ctx {
+		args = append(args, reflect.ValueOf(&ctx).Elem())
+	}
+	switch s.name {
+	case nameString:
+		args = append(args, reflect.ValueOf(field.Name))
+	case nameField:
+		args = append(args, reflect.ValueOf(field))
+	}
+	if s.value {
+		args = append(args, value)
+	}
+	return append(args, params...)
+}
+
+//results returns errors returned by the method
+func (s signature) results(results []reflect.Value) []error {
+	if s.errors {
+		return Append(nil, results[0].Interface().([]error)...)
+	}
+	if isNil(results[0]) {
+		return nil
+	}
+	return []error{results[0].Interface().(error)}
+}
+
+//lookupMethod returns the method of the call check. Methods of the owner
+//are looked up first, then methods of the checked value
+func lookupMethod(owner reflect.Value, value reflect.Value, name string) (reflect.Value, bool) {
+	if owner.IsValid() {
+		if method := ownerMethod(owner, name); method.IsValid() {
+			return method, true
+		}
+	}
+	if v := elem(value); v.IsValid() && v.Kind() != reflect.Interface {
+		if method := v.MethodByName(name); method.IsValid() {
+			return method, false
+		}
+	}
+	return reflect.Value{}, false
+}
+
+//ownerMethod returns the method of the owner including methods with the pointer
+//receiver like Compile does. Owners which are not addressable are copied
+func ownerMethod(owner reflect.Value, name string) reflect.Value {
+	owner = elem(owner)
+	if owner.Kind() == reflect.Struct && (owner.CanAddr() || owner.CanInterface()) {
+		owner = addressable(owner).Addr()
+	}
+	return owner.MethodByName(name)
+}
+
+//lookupCall returns the method or the registered function of the call check
+//and whether it accepts the value
+func lookupCall(owner reflect.Value, value reflect.Value, name string) (reflect.Value, bool) {
+	if method, isOwner := lookupMethod(owner, value, name); method.IsValid() {
+		return method, isOwner
+	}
+	return lookupFunc(name)
+}
+
+func withMethod(ctx context.Context, root reflect.Value, field *node, r rule) []error {
+	strField := field.Struct()
+	c, ok := parseCall(r.arg)
+	if !ok {
+		return []error{newError(ErrBadSyntax, strField.Name, r.text, ErrorType)}
+	}
+	value := field.Value()
+	method, owner := lookupCall(root, value, c.name)
+	if !method.IsValid() {
+		return []error{fmt.Errorf("method not found: %s", c.name)}
+	}
+	return callMethod(ctx, method, owner, Field{Name: strField.Name, Path: field.Path()}, value, r, c)
+}
+
+//callMethod calls the method or the registered function of the call check
+func callMethod(ctx context.Context, method reflect.Value, owner bool, field Field, value reflect.Value, r rule, c callRule) []error {
+	sig, ok := parseSignature(method.Type(), 0, value.Type(), owner, len(c.args))
+	if !ok {
+		return []error{newError(ErrWrongSignatureMethod, field.Name, r.text, ErrorType)}
+	}
+	params, ok := sig.values(c.args)
+	if !ok {
+		return []error{newError(ErrBadSyntax, field.Name, r.text, ErrorType)}
+	}
+	return sig.results(method.Call(sig.args(ctx, field, value, params)))
+}
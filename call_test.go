@@ -0,0 +1,265 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCtxKey struct{}
+
+type testLevel string
+
+//Valid is the method of the checked value
+func (l testLevel) Valid(name string) error {
+	if l == "" || l == "info" {
+		return nil
+	}
+	return fmt.Errorf("%s: unknown level %q", name, string(l))
+}
+
+//Set is not in the method set of testLevel
+func (l *testLevel) Set() error {
+	return nil
+}
+
+type testCall struct {
+	Region  string      `check:"call:CheckRegion"`
+	Comment string      `check:"call:CheckComment"`
+	Retries []int       `check:"dive,call:CheckRetry"`
+	Level   testLevel   `check:"call:Valid"`
+	Levels  []testLevel `check:"dive,call:Valid"`
+}
+
+func (c testCall) CheckRegion(ctx context.Context, field Field, value string) []error {
+	if value == "" {
+		return nil
+	}
+	return []error{fmt.Errorf("%s: unknown region %q (%v)", field.Path, value, ctx.Value(testCtxKey{})), nil}
+}
+
+func (c testCall) CheckComment(value string) error {
+	if len(value) > 5 {
+		return errors.New("comment is too long")
+	}
+	return nil
+}
+
+func (c *testCall) CheckRetry(field Field, value int) error {
+	if value > 3 {
+		return fmt.Errorf("%s: too many retries", field.Path)
+	}
+	return nil
+}
+
+type testCallWrong struct {
+	Names string    `check:"call:TwoNames"`
+	Level testLevel `check:"call:Set"`
+	Out   string    `check:"call:NoError"`
+}
+
+func (c testCallWrong) TwoNames(name string, field Field, value string) error {
+	return nil
+}
+
+func (c testCallWrong) NoError(value string) bool {
+	return false
+}
+
+type testCallArgs struct {
+	Retry   int     `check:"call:Range(1,3)"`
+	Ratio   float32 `check:"call:MaxRatio(0.5, true)"`
+	Code    string  `check:"call:testPrefix(ab)"`
+	BadArg  int     `check:"call:Range(1,x)"`
+	NoArgs  int     `check:"call:Range"`
+	Open    int     `check:"call:Range(1,3"`
+	BadFunc string  `check:"call:testBadFunc"`
+}
+
+func (c testCallArgs) Range(name string, value int, min, max int8) error {
+	if value < int(min) || value > int(max) {
+		return fmt.Errorf("%s: %d is out of range [%d, %d]", name, value, min, max)
+	}
+	return nil
+}
+
+func (c testCallArgs) MaxRatio(value float32, max float64, strict bool) error {
+	if strict && float64(value) >= max {
+		return errors.New("ratio is too large")
+	}
+	return nil
+}
+
+func init() {
+	RegisterFunc("testPrefix", func(field Field, value string, prefix string) []error {
+		if strings.HasPrefix(value, prefix) {
+			return nil
+		}
+		return []error{fmt.Errorf("%s: no prefix %q", field.Path, prefix)}
+	})
+	RegisterFunc("testBadFunc", func(value int) error { return nil })
+}
+
+func TestParseCall(t *testing.T) {
+	cases := []struct {
+		arg  string
+		want callRule
+		ok   bool
+	}{
+		{"Method", callRule{name: "Method"}, true},
+		{"Method()", callRule{name: "Method"}, true},
+		{"Method(1, b ,c)", callRule{name: "Method", args: []string{"1", "b", "c"}}, true},
+		{"", callRule{}, false},
+		{"(1)", callRule{}, false},
+		{"Method(1", callRule{}, false},
+	}
+	for _, c := range cases {
+		got, ok := parseCall(c.arg)
+		assert.Equal(t, c.ok, ok, c.arg)
+		assert.Equal(t, c.want, got, c.arg)
+	}
+
+	sig, ok := parseSignature(reflect.TypeOf(testCallArgs.Range), 1, reflect.TypeOf(0), true, 2)
+	assert.True(t, ok)
+	values, ok := sig.values([]string{"1", "127"})
+	assert.True(t, ok)
+	assert.Equal(t, int8(127), values[1].Interface())
+	_, ok = sig.values([]string{"1", "128"})
+	assert.False(t, ok)
+	_, ok = parseSignature(reflect.TypeOf(testCallArgs.Range), 1, reflect.TypeOf(0), true, 3)
+	assert.False(t, ok)
+	_, ok = parseSignature(reflect.TypeOf(func(int, []string) error { return nil }), 0, reflect.TypeOf(0), true, 1)
+	assert.False(t, ok)
+}
+
+func TestRegisterFunc(t *testing.T) {
+	assert.Panics(t, func() { RegisterFunc("bad", "func") })
+	_, ok := lookupFunc("testPrefix")
+	assert.True(t, ok)
+	_, ok = lookupFunc("testMissing")
+	assert.False(t, ok)
+}
+
+func TestCallArgs(t *testing.T) {
+	errs := New(ModeAll, ErrorAll).Check(&testCallArgs{Retry: 5, Ratio: 0.7, Code: "xy"})
+	assert.Len(t, errs, 7)
+	assert.EqualError(t, errs[0], "Retry: 5 is out of range [1, 3]")
+	assert.EqualError(t, errs[1], "ratio is too large")
+	assert.EqualError(t, errs[2], `Code: no prefix "ab"`)
+	assert.EqualError(t, errs[3], "bad syntax: BadArg call:Range(1,x)")
+	assert.EqualError(t, errs[4], "wrong signature method: NoArgs call:Range")
+	assert.EqualError(t, errs[5], "bad syntax: Open call:Range(1,3")
+	assert.EqualError(t, errs[6], "wrong signature method: BadFunc call:testBadFunc")
+
+	err := Compile(reflect.TypeOf(testCallArgs{}))
+	assert.EqualError(t, err, "invalid check tags of checks.testCallArgs:\n"+
+		"\tbad syntax: BadArg call:Range(1,x)\n"+
+		"\twrong signature method: NoArgs call:Range\n"+
+		"\tbad syntax: Open call:Range(1,3\n"+
+		"\twrong signature method: BadFunc call:testBadFunc")
+}
+
+func TestParseSignature(t *testing.T) {
+	str := reflect.TypeOf("")
+	cases := []struct {
+		method interface{}
+		owner  bool
+		want   signature
+		ok     bool
+	}{
+		{func(string, string) error { return nil }, true, signature{name: nameString, value: true}, true},
+		{func(interface{}, string) error { return nil }, true, signature{name: nameString, value: true}, true},
+		{func(string) error { return nil }, true, signature{value: true}, true},
+		{func(context.Context, Field, string) []error { return nil }, true,
+			signature{ctx: true, name: nameField, value: true, errors: true}, true},
+		{func(context.Context) error { return nil }, false, signature{ctx: true}, true},
+		{func(Field) error { return nil }, false, signature{name: nameField}, true},
+		{func() error { return nil }, false, signature{}, true},
+		{func() error { return nil }, true, signature{}, false},
+		{func(int) error { return nil }, true, signature{}, false},
+		{func(string, string, string) error { return nil }, true, signature{}, false},
+		{func(string) bool { return false }, true, signature{}, false},
+		{func(string) (error, error) { return nil, nil }, true, signature{}, false},
+		{func(...string) error { return nil }, false, signature{}, false},
+		{func(int) error { return nil }, false, signature{}, false},
+	}
+	for i, c := range cases {
+		sig, ok := parseSignature(reflect.TypeOf(c.method), 0, str, c.owner, 0)
+		assert.Equal(t, c.ok, ok, i)
+		if c.ok {
+			assert.Equal(t, c.want, sig, i)
+		}
+	}
+}
+
+func TestCallSignatures(t *testing.T) {
+	ctx := context.WithValue(context.Background(), testCtxKey{}, "ctx")
+	errs := New(ModeAll, ErrorAll).CheckContext(ctx, &testCall{
+		Region:  "mars",
+		Comment: "too long",
+		Retries: []int{1, 5},
+		Level:   "trace",
+		Levels:  []testLevel{"info", "warn"},
+	})
+	assert.Len(t, errs, 5)
+	assert.EqualError(t, errs[0], `Region: unknown region "mars" (ctx)`)
+	assert.EqualError(t, errs[1], "comment is too long")
+	assert.EqualError(t, errs[2], "Retries[1]: too many retries")
+	assert.EqualError(t, errs[3], `Level: unknown level "trace"`)
+	assert.EqualError(t, errs[4], `Levels: unknown level "warn"`)
+
+	assert.NoError(t, Check(testCall{}))
+
+	errs = New(ModeAll, ErrorAll).Check(testCallWrong{})
+	assert.Len(t, errs, 3)
+	assert.EqualError(t, errs[0], "wrong signature method: Names call:TwoNames")
+	assert.EqualError(t, errs[1], "method not found: Set")
+	assert.EqualError(t, errs[2], "wrong signature method: Out call:NoError")
+}
+
+type testCallInner struct {
+	Name string `check:"call:CheckName"`
+}
+
+func (c *testCallInner) CheckName(value string) error {
+	if value == "" {
+		return errors.New("name is empty")
+	}
+	return nil
+}
+
+type testCallOuter struct {
+	Inner  testCallInner
+	Inners []testCallInner
+}
+
+func TestCallPointerReceiver(t *testing.T) {
+	v := testCallOuter{Inners: []testCallInner{{Name: "a"}, {}}}
+	assert.NoError(t, Compile(reflect.TypeOf(v)))
+	for _, value := range []interface{}{&v, v} {
+		errs := CheckAll(value)
+		assert.Len(t, errs, 2)
+		assert.EqualError(t, errs[0], "name is empty")
+		assert.EqualError(t, errs[1], "name is empty")
+	}
+	assert.NoError(t, Check(&testCallOuter{Inner: testCallInner{Name: "a"}}))
+}
+
+func TestCompileCall(t *testing.T) {
+	assert.NoError(t, Compile(reflect.TypeOf(testCall{})))
+
+	err := Compile(reflect.TypeOf(testCallWrong{}))
+	assert.EqualError(t, err, "invalid check tags of checks.testCallWrong:\n"+
+		"\twrong signature method: Names call:TwoNames\n"+
+		"\tmethod not found: Level call:Set\n"+
+		"\twrong signature method: Out call:NoError")
+
+	assert.NoError(t, Compile(reflect.TypeOf(struct {
+		Plugin interface{} `check:"call:Valid"`
+	}{})))
+}
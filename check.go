@@ -1,11 +1,10 @@
 package checks
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
-	"regexp"
-	"strings"
 )
 
 //Errors
@@ -15,8 +14,16 @@ var (
 	ErrDeprecated           = errors.New("deprecated parameter")
 	ErrWrongSignatureMethod = errors.New("wrong signature method")
 	ErrNoMatch              = errors.New("no matches")
+	ErrMatch                = errors.New("unexpected match")
 	ErrBadSyntax            = errors.New("bad syntax")
 	ErrSkip                 = errors.New("skip")
+	ErrUnknownCheck         = errors.New("unknown check")
+	ErrMethodNotFound       = errors.New("method not found")
+	ErrFieldNotFound        = errors.New("field not found")
+	ErrTooShort             = errors.New("too short")
+	ErrUnexported           = errors.New("unexported field")
+	ErrDuplicate            = errors.New("duplicate value")
+	ErrPatternNotFound      = errors.New("pattern not found")
 )
 
 //Known check modes
@@ -36,9 +43,21 @@ type (
 
 	//SimpeChecker implements simple checks: required, expect, deprecated
 	SimpeChecker struct {
-		mode      Mode
-		errorMode Type
+		mode       Mode
+		errorMode  Type
+		generated  bool
+		workers    int
+		limit      int
+		bail       bool
+		skipAll    bool
+		groups     map[string]bool
+		unexported Unexported
+
+		normalizedCopy bool
 	}
+
+	//Option configures SimpeChecker
+	Option func(c *SimpeChecker)
 )
 
 func isZero(value reflect.Value) bool {
@@ -67,89 +86,35 @@ func isZero(value reflect.Value) bool {
 }
 
 func required(value reflect.Value, strField *reflect.StructField) error {
-	if isNil(value) || !value.IsValid() || isZero(value) {
-		return newError(ErrValueRequired, strField.Name, nil, ErrorType)
-	}
-	return nil
+	return Required(strField.Name, isNil(value) || !value.IsValid() || isZero(value))
 }
 
 func deprecated(value reflect.Value, strField *reflect.StructField) error {
-	if isNil(value) || !value.IsValid() || isZero(value) {
-		return nil
-	}
-	return newError(ErrDeprecated, strField.Name, nil, WarningType)
+	return Deprecated(strField.Name, isNil(value) || !value.IsValid() || isZero(value))
 }
 
-func expect(value reflect.Value, strField *reflect.StructField) error {
-	value = reflect.Indirect(value)
-	sTag := strField.Tag.Get("check")
-	sTagValues := strings.SplitN(sTag, ":", 2)
-	if len(sTagValues) != 2 || sTagValues[1] == "" {
-		return newError(ErrBadSyntax, strField.Name, sTag, ErrorType)
+func withRegexp(value reflect.Value, strField *reflect.StructField, r rule) error {
+	if r.arg == "" {
+		return newError(ErrBadSyntax, strField.Name, r.text, ErrorType)
 	}
-	sTagValues = strings.Split(sTagValues[1], ";")
-
-	if isNil(value) || !value.IsValid() {
-		return newError(ErrValueUnexpected, strField.Name, "<nil>", ErrorType)
-	}
-
-	sValue := fmt.Sprintf("%v", value.Interface())
-	if !hasValue(sValue, sTagValues) {
-		return newError(ErrValueUnexpected, strField.Name, value.Interface(), ErrorType)
-	}
-	return nil
-}
-
-func withMethod(root reflect.Value, value reflect.Value, strField *reflect.StructField) error {
-	sTag := strField.Tag.Get("check")
-	sTagValues := strings.SplitN(sTag, ":", 2)
-	if len(sTagValues) != 2 || sTagValues[1] == "" {
-		return newError(ErrBadSyntax, strField.Name, sTag, ErrorType)
-	}
-	methodName := sTagValues[1]
-	methodValue := root.MethodByName(methodName)
-	if !methodValue.IsValid() {
-		return fmt.Errorf("method not found: %s", methodName)
-	}
-
-	errValues := methodValue.Call([]reflect.Value{reflect.ValueOf(strField.Name), value})
-	errSignature := newError(ErrWrongSignatureMethod, strField.Name, sTag, ErrorType)
-	if len(errValues) != 1 {
-		return errSignature
-	}
-
-	if isNil(errValues[0]) {
-		return nil
-	}
-
-	err, ok := errValues[0].Interface().(error)
-	if !ok {
-		return errSignature
-	}
-	return err
-}
-
-func withRegexp(root reflect.Value, value reflect.Value, strField *reflect.StructField) error {
-	sTag := strField.Tag.Get("check")
-	sTagValues := strings.SplitN(sTag, ":", 2)
-	if len(sTagValues) != 2 || sTagValues[1] == "" {
-		return newError(ErrBadSyntax, strField.Name, sTag, ErrorType)
-	}
-	reString := sTagValues[1]
 
+	negated := r.negated()
 	if isNil(value) || !value.IsValid() {
+		//nil values match nothing
+		if negated {
+			return nil
+		}
 		return newError(ErrValueUnexpected, strField.Name, "<nil>", ErrorType)
 	}
 
-	sValue := fmt.Sprintf("%v", value.Interface())
-	matched, err := regexp.MatchString(reString, sValue)
+	re, err := r.regexp()
 	if err != nil {
-		return newError(err, strField.Name, sTag, ErrorType)
+		return newError(err, strField.Name, r.text, ErrorType)
 	}
-	if !matched {
-		return newError(ErrNoMatch, strField.Name, sTag, ErrorType)
+	if negated {
+		return NotMatch(strField.Name, r.text, re, value.Interface())
 	}
-	return nil
+	return Match(strField.Name, r.text, re, value.Interface())
 }
 
 func interfaceChecker(value reflect.Value) error {
@@ -163,7 +128,7 @@ func interfaceChecker(value reflect.Value) error {
 	return check.Check()
 }
 
-func checkValue(v Value, parent Value) []error {
+func (c *SimpeChecker) checkValue(ctx context.Context, v *node, parent Value) []error {
 	value := v.Value()
 	if err := interfaceChecker(value); err != nil {
 		return []error{err}
@@ -172,40 +137,76 @@ func checkValue(v Value, parent Value) []error {
 	if v.Struct() == nil {
 		return nil
 	}
+	if v.unexported {
+		return []error{newError(ErrUnexported, v.Struct().Name, nil, ErrorType)}
+	}
 
 	sTag, ok := v.Tag().Lookup("check")
 	if !ok {
+		return Append(nil, enum(v.Struct().Name, value))
+	}
+
+	rules, keys, _, dive, ok := splitDive(parseTag(sTag))
+	if syntax := diveSyntax(v.Struct().Type, keys, dive, ok); syntax != "" {
+		return []error{newError(ErrBadSyntax, v.Struct().Name, syntax, ErrorType)}
+	}
+	if ok, err := groups(c.groups, v.Struct().Name, rules); !ok {
+		if err != nil {
+			return []error{err}
+		}
+		return nil
+	}
+	if ok, err := conditions(parent.Value(), v.Struct(), rules); !ok {
+		if err != nil {
+			return []error{err}
+		}
 		return nil
 	}
 
-	tagsChecks := strings.Split(sTag, ",")
 	var result []error
-	for _, tagCheck := range tagsChecks {
-		var errCheck error
-		switch tagCheck {
-		case "required":
-			errCheck = required(value, v.Struct())
-		case "deprecated":
-			errCheck = deprecated(value, v.Struct())
-		default:
-			if strings.HasPrefix(tagCheck, "expect:") {
-				errCheck = expect(value, v.Struct())
-			} else if strings.HasPrefix(tagCheck, "call:") {
-				errCheck = withMethod(parent.Value(), value, v.Struct())
-			} else if strings.HasPrefix(tagCheck, "re:") {
-				errCheck = withRegexp(parent.Value(), value, v.Struct())
-			} else {
-				errCheck = fmt.Errorf("unknown check: %s", tagCheck)
+	if !hasExpect(rules) {
+		if err := enum(v.Struct().Name, value); err != nil {
+			result = append(result, err)
+			if c.bail && acceptType(err, c.errorMode) {
+				return result
 			}
 		}
-		if errCheck != nil {
-			result = append(result, errCheck)
-		}
 	}
-	if len(result) == 0 {
-		return nil
+	for _, r := range rules {
+		var errs []error
+		switch {
+		case r.name == ruleIf, r.name == ruleGroup:
+		case !r.known():
+			errs = Append(errs, fmt.Errorf("unknown check: %s", r.text))
+		case r.name == ruleKeys, r.name == ruleEndKeys:
+			errs = Append(errs, newError(ErrBadSyntax, v.Struct().Name, r.text, ErrorType))
+		case r.normalizes():
+			//values are normalized before checks
+			if !stringType(v.Struct().Type) {
+				errs = Append(errs, newError(ErrBadSyntax, v.Struct().Name, r.text, ErrorType))
+			}
+		case r.name == ruleRequired:
+			errs = Append(errs, required(value, v.Struct()))
+		case r.name == ruleDeprecated:
+			errs = Append(errs, deprecated(value, v.Struct()))
+		case r.name == ruleExpect, r.name == ruleIExpect:
+			errs = Append(errs, expect(ctx, parent.Value(), value, v.Struct(), r))
+		case r.name == ruleCall:
+			errs = withMethod(ctx, parent.Value(), v, r)
+		case r.matches():
+			errs = Append(errs, withRegexp(value, v.Struct(), r))
+		case r.name == ruleMinLen:
+			errs = Append(errs, minlen(value, v.Struct(), r))
+		case r.name == ruleUnique:
+			errs = unique(value, v.Struct(), r)
+		}
+		for _, e := range errs {
+			result = append(result, e)
+			if c.bail && acceptType(e, c.errorMode) {
+				return result
+			}
+		}
 	}
-
 	return result
 }
 
@@ -227,43 +228,85 @@ func isInterface(value reflect.Value) bool {
 	return value.IsValid() && !isNil(value) && value.CanInterface()
 }
 
+//WithLimit stops checking after n results
+func WithLimit(n int) Option {
+	return func(c *SimpeChecker) {
+		c.limit = n
+	}
+}
+
+//WithBail stops checking rules of the field after the first failed result.
+//Only the first result of rules returning several results is reported,
+//e.g. the first duplicate of unique or the first error of call returning []error
+func WithBail() Option {
+	return func(c *SimpeChecker) {
+		c.bail = true
+	}
+}
+
+//WithSkipAll makes ErrSkip returned by any value skip checks of the entire value
+//passed to Check. By default ErrSkip skips only the value that returned it and
+//its nested values
+func WithSkipAll() Option {
+	return func(c *SimpeChecker) {
+		c.skipAll = true
+	}
+}
+
+//WithGenerated enables check functions registered by generated code.
+//Types without generated functions are checked using reflection
+func WithGenerated() Option {
+	return func(c *SimpeChecker) {
+		c.generated = true
+	}
+}
+
 //New returns new checker
-func New(m Mode, e Type) *SimpeChecker {
-	return &SimpeChecker{
+func New(m Mode, e Type, opts ...Option) *SimpeChecker {
+	c := &SimpeChecker{
 		mode:      m,
 		errorMode: e,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 //Check checks value
 func (c *SimpeChecker) Check(v interface{}) []error {
-	result := make([]error, 0)
-	iter := newIterator(v)
-	for iter.HasNext() {
-		item := iter.Next()
-		if err := checkValue(item, item.Parent()); err != nil {
-			if len(err) != 0 && err[0] == ErrSkip {
-				return nil
-			}
+	return c.CheckContext(context.Background(), v)
+}
 
-			for _, e := range err {
-				if are, ok := e.(ErrorCheckResult); ok {
-					typ := are.GetType()
-					if (typ & c.errorMode) != typ {
-						continue
-					}
-				}
-				result = append(result, e)
-				if c.mode == ModeFirst {
-					return result
-				}
+//CheckContext checks value. The context is passed to methods of call checks accepting it
+func (c *SimpeChecker) CheckContext(ctx context.Context, v interface{}) []error {
+	ctx = withValuesCache(ctx)
+	collector := c.newCollector(ctx)
+	v = c.normalize(v)
+	if c.generated {
+		if fn, ok := lookupGenerated(v); ok {
+			if value := reflect.ValueOf(v); !isNil(value) {
+				fn(collector, v)
 			}
+			return collector.Result()
 		}
 	}
-	if len(result) == 0 {
-		return nil
+
+	iter := newIterator(v, c.unexported)
+	if c.workers > 1 {
+		c.checkParallel(iter, collector)
+		return collector.Result()
 	}
-	return result
+	for iter.HasNext() {
+		item := iter.next()
+		if !collector.Add(withPath(c.checkValue(ctx, item, item.owner), item.Path)...) {
+			break
+		}
+		if collector.Skipped() {
+			iter.skipChildren()
+		}
+	}
+	return collector.Result()
 }
 
 //Check check structure
@@ -1,6 +1,7 @@
 package checks
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -303,6 +304,54 @@ func TestCheckTagsAndChecker(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+type testSkipNested struct {
+	Enabled bool
+	Name    string `check:"required"`
+	Items   []testSkipItem
+}
+
+type testSkipItem struct {
+	Value string `check:"required"`
+}
+
+func (n testSkipNested) Check() error {
+	if !n.Enabled {
+		return ErrSkip
+	}
+	return nil
+}
+
+type testSkipRoot struct {
+	Listen   string `check:"required"`
+	Disabled testSkipNested
+	Enabled  *testSkipNested
+	List     []testSkipNested
+	Port     int `check:"required"`
+}
+
+func TestCheckScopedSkip(t *testing.T) {
+	value := &testSkipRoot{
+		Disabled: testSkipNested{Items: []testSkipItem{{}}},
+		Enabled:  &testSkipNested{Enabled: true, Items: []testSkipItem{{}}},
+		List: []testSkipNested{
+			{Items: []testSkipItem{{}}},
+			{Enabled: true, Name: "name"},
+		},
+	}
+	errs := CheckAll(value)
+	assert.Len(t, errs, 4)
+	assert.EqualError(t, errs[0], "value required: Listen")
+	assert.EqualError(t, errs[1], "value required: Enabled.Name")
+	assert.EqualError(t, errs[2], "value required: Enabled.Items[0].Value")
+	assert.EqualError(t, errs[3], "value required: Port")
+
+	errs = New(ModeAll, ErrorAll, WithSkipAll()).Check(value)
+	assert.Nil(t, errs)
+
+	err := Check(value)
+	assert.EqualError(t, err, "value required: Listen")
+}
+
 func TestChecker(t *testing.T) {
 	c := New(ModeAll, ErrorAll)
 	assert.NotNil(t, c)
@@ -505,6 +554,136 @@ func TestRegexp(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+type testMatchText int
+
+func (m testMatchText) String() string {
+	return "text"
+}
+
+func TestMatchRules(t *testing.T) {
+	type testMatch struct {
+		Search  string        `check:"re:[a-z]+"`
+		Full    string        `check:"match:[a-z]+"`
+		NoSpace string        `check:"!re:\\s"`
+		NotRoot *string       `check:"!match:admin|root"`
+		Ptr     *string       `check:"match:[a-z]+"`
+		Text    testMatchText `check:"match:text"`
+		Port    int           `check:"match:[0-9][0-9]+"`
+		Raw     []byte        `check:"!match:"`
+		Bad     string        `check:"match:[a-z"`
+	}
+	name, root := "abc", "root"
+	errs := New(ModeAll, ErrorAll).Check(&testMatch{
+		Search:  "123abc",
+		Full:    "abc",
+		NoSpace: "a_b",
+		NotRoot: &name,
+		Ptr:     &name,
+		Port:    8080,
+		Raw:     []byte("x"),
+	})
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "bad syntax: Raw !match:")
+	assert.EqualError(t, errs[1], "error parsing regexp: missing closing ]: `[a-z`: Bad match:[a-z")
+
+	errs = New(ModeAll, ErrorAll).Check(&testMatch{
+		Search:  "123",
+		Full:    "123abc",
+		NoSpace: "a b",
+		NotRoot: &root,
+		Text:    1,
+		Port:    1,
+	})
+	assert.Len(t, errs, 8)
+	assert.EqualError(t, errs[0], "no matches: Search re:[a-z]+")
+	assert.EqualError(t, errs[1], "no matches: Full match:[a-z]+")
+	assert.EqualError(t, errs[2], "unexpected match: NoSpace !re:\\s")
+	assert.EqualError(t, errs[3], "unexpected match: NotRoot !match:admin|root")
+	assert.EqualError(t, errs[4], "unexpected value: Ptr <nil>")
+	assert.EqualError(t, errs[5], "no matches: Port match:[0-9][0-9]+")
+	assert.EqualError(t, errs[6], "bad syntax: Raw !match:")
+	assert.EqualError(t, errs[7], "error parsing regexp: missing closing ]: `[a-z`: Bad match:[a-z")
+}
+
+func TestCheckLimit(t *testing.T) {
+	type testLimit struct {
+		A int    `check:"required,expect:1;2"`
+		B string `check:"required"`
+		C string `check:"deprecated"`
+	}
+	value := testLimit{C: "c"}
+	errs := New(ModeAll, ErrorAll, WithLimit(2)).Check(value)
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "value required: A")
+	assert.EqualError(t, errs[1], "unexpected value: A 0")
+
+	errs = New(ModeAll, ErrorAll, WithLimit(10)).Check(value)
+	assert.Len(t, errs, 4)
+
+	errs = New(ModeFirst, ErrorAll, WithLimit(10)).Check(value)
+	assert.Len(t, errs, 1)
+}
+
+type testBailCall struct {
+	Name string `check:"call:CheckName"`
+}
+
+func (c testBailCall) CheckName(value string) []error {
+	return []error{errors.New("first"), errors.New("second")}
+}
+
+func TestCheckBail(t *testing.T) {
+	type testBail struct {
+		LogLevel string `check:"required,expect:info;debug;error"`
+		Timeout  int    `check:"deprecated,required,expect:1;2"`
+	}
+	errs := New(ModeAll, ErrorAll, WithBail()).Check(testBail{Timeout: 3})
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "value required: LogLevel")
+	assert.EqualError(t, errs[1], "deprecated parameter: Timeout")
+
+	errs = New(ModeAll, ErrorType, WithBail()).Check(testBail{Timeout: 3})
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "value required: LogLevel")
+	assert.EqualError(t, errs[1], "unexpected value: Timeout 3")
+
+	errs = New(ModeAll, ErrorType, WithBail(), WithLimit(1)).Check(testBail{})
+	assert.Len(t, errs, 1)
+
+	type testBailMany struct {
+		Hosts []string `check:"unique,required"`
+	}
+	errs = New(ModeAll, ErrorAll, WithBail()).Check(testBailMany{Hosts: []string{"a", "a", "b", "b"}})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "duplicate value: Hosts[1] a")
+	errs = New(ModeAll, ErrorAll).Check(testBailMany{Hosts: []string{"a", "a", "b", "b"}})
+	assert.Len(t, errs, 2)
+
+	errs = New(ModeAll, ErrorAll, WithBail()).Check(testBailCall{})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "first")
+	errs = New(ModeAll, ErrorAll).Check(testBailCall{})
+	assert.Len(t, errs, 2)
+
+	c := New(ModeAll, ErrorAll, WithBail()).newCollector(context.Background())
+	assert.True(t, c.Add(newError(ErrDeprecated, "F", nil, WarningType), ErrBadSyntax))
+	assert.Len(t, c.Result(), 1)
+}
+
+func TestCheckRuleArguments(t *testing.T) {
+	type testRules struct {
+		Level string `check:"expect:info;debug,required"`
+		Name  string `check:"required,re:^[a-z]+$"`
+	}
+	errs := CheckAll(testRules{Level: "warn", Name: "1"})
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "unexpected value: Level warn")
+	assert.EqualError(t, errs[1], "no matches: Name re:^[a-z]+$")
+
+	errs = CheckAll(testRules{Level: "info", Name: "name"})
+	assert.Len(t, errs, 0)
+}
+
 func TestCheckInvalid(t *testing.T) {
 	type testInvalid struct {
 		Value string `check:"custom"`
@@ -513,3 +692,33 @@ func TestCheckInvalid(t *testing.T) {
 	err := Check(v)
 	assert.EqualError(t, err, "unknown check: custom")
 }
+
+type testPluginConfig struct {
+	Enabled bool
+	Address string `check:"required,if:Enabled,call:CheckAddress"`
+}
+
+func (p *testPluginConfig) CheckAddress(name string, address string) error {
+	if address == "bad" {
+		return errors.New("bad address")
+	}
+	return nil
+}
+
+func TestCheckInterfaceValues(t *testing.T) {
+	type testPlugins struct {
+		Plugin  interface{}
+		Plugins map[string]interface{}
+	}
+	errs := CheckAll(testPlugins{
+		Plugin: &testPluginConfig{Enabled: true},
+		Plugins: map[string]interface{}{
+			"b": &testPluginConfig{Enabled: true, Address: "bad"},
+			"a": testPluginConfig{Enabled: true, Address: "a"},
+		},
+	})
+	//methods with the pointer receiver are called on copies of values which are not addressable
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "value required: Plugin.Address")
+	assert.EqualError(t, errs[1], "bad address")
+}
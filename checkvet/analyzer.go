@@ -0,0 +1,610 @@
+//Package checkvet defines an Analyzer that reports mistakes in `check` tags
+package checkvet
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `check "check" struct tags
+
+The checkvet analyzer reports unknown rules, malformed expect, re and match rules,
+regular expressions that fail to compile, call rules referencing missing
+methods or methods with the wrong signature, if rules referencing missing
+fields, dive rules of values other than slices, arrays and maps, unique
+rules of values other than slices and arrays and normalization rules of
+values other than strings.
+Functions registered by checks.RegisterFunc and checks.RegisterValues,
+aliases and regular expressions registered by checks.RegisterAlias and
+checks.RegisterPattern in the package or in its dependencies are known.`
+
+//Analyzer reports mistakes in `check` tags
+var Analyzer = &analysis.Analyzer{
+	Name:      "checkvet",
+	Doc:       doc,
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(registered)},
+}
+
+type (
+	//registered is the fact of the package holding names of functions registered
+	//by checks.RegisterFunc and checks.RegisterValues, aliases and regular expressions
+	registered struct {
+		Names []string
+	}
+
+	//funcs are registered functions known to the pass. Signatures of functions
+	//registered in dependencies are nil, functions of expect:@Name are keyed by @Name,
+	//aliases and regular expressions by alias:Name and pattern:Name
+	funcs map[string]*types.Signature
+)
+
+//AFact implements analysis.Fact
+func (*registered) AFact() {}
+
+func (r *registered) String() string {
+	return "registered(" + strings.Join(r.Names, ", ") + ")"
+}
+
+const checksPath = "github.com/arteev/go-checks"
+
+var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	known := registeredFuncs(pass, inspect)
+
+	nodeFilter := []ast.Node{
+		(*ast.StructType)(nil),
+	}
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		st := n.(*ast.StructType)
+		typ, ok := pass.TypesInfo.Types[st]
+		if !ok {
+			return true
+		}
+		parent := parentType(pass, st, stack, typ.Type)
+		for _, field := range st.Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+			tag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				continue
+			}
+			sTag, ok := reflect.StructTag(tag).Lookup("check")
+			if !ok {
+				continue
+			}
+			fieldType := pass.TypesInfo.TypeOf(field.Type)
+			checkTag(pass, field, sTag, fieldType, parent, known)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+//parentType returns the named type declared with the struct type if any.
+//The stack holds the enclosing nodes of the struct type
+func parentType(pass *analysis.Pass, st *ast.StructType, stack []ast.Node, typ types.Type) types.Type {
+	if len(stack) < 2 {
+		return typ
+	}
+	spec, ok := stack[len(stack)-2].(*ast.TypeSpec)
+	if !ok || spec.Type != st {
+		return typ
+	}
+	if named, ok := pass.TypesInfo.Defs[spec.Name].Type().(*types.Named); ok {
+		return named
+	}
+	return typ
+}
+
+//registeredFuncs returns functions registered in the package and in its dependencies
+//and exports names of functions registered in the package
+func registeredFuncs(pass *analysis.Pass, inspect *inspector.Inspector) funcs {
+	known := make(funcs)
+	for _, fact := range pass.AllPackageFacts() {
+		for _, name := range fact.Fact.(*registered).Names {
+			known[name] = nil
+		}
+	}
+	var names []string
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || len(call.Args) != 2 {
+			return
+		}
+		fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != checksPath {
+			return
+		}
+		name := pass.TypesInfo.Types[call.Args[0]].Value
+		if name == nil || name.Kind() != constant.String {
+			return
+		}
+		switch fn.Name() {
+		case "RegisterFunc":
+			sig, _ := pass.TypesInfo.TypeOf(call.Args[1]).Underlying().(*types.Signature)
+			known[constant.StringVal(name)] = sig
+			names = append(names, constant.StringVal(name))
+		case "RegisterValues":
+			//functions returning values of expect:@Name are known by @Name
+			known["@"+constant.StringVal(name)] = nil
+			names = append(names, "@"+constant.StringVal(name))
+		case "RegisterAlias":
+			known["alias:"+constant.StringVal(name)] = nil
+			names = append(names, "alias:"+constant.StringVal(name))
+		case "RegisterPattern":
+			known["pattern:"+constant.StringVal(name)] = nil
+			names = append(names, "pattern:"+constant.StringVal(name))
+		}
+	})
+	if len(names) > 0 {
+		pass.ExportPackageFact(&registered{Names: names})
+	}
+	return known
+}
+
+func checkTag(pass *analysis.Pass, field *ast.Field, sTag string, fieldType types.Type, parent types.Type, known funcs) {
+	checkRules(pass, field, splitTag(sTag), fieldType, parent, known)
+}
+
+//splitTag splits the tag into rules. Commas inside the parentheses of the call rule
+//separate arguments of the method, not rules
+func splitTag(sTag string) []string {
+	var (
+		result []string
+		depth  int
+		start  int
+	)
+	for i := 0; i < len(sTag); i++ {
+		switch sTag[i] {
+		case '(':
+			if strings.HasPrefix(sTag[start:], "call:") {
+				depth++
+			}
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				result = append(result, sTag[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(result, sTag[start:])
+}
+
+//checkRules checks rules of the value of fieldType and rules of its map keys and elements
+func checkRules(pass *analysis.Pass, field *ast.Field, rules []string, fieldType types.Type, parent types.Type, known funcs) {
+	var dive []string
+	for i, tagCheck := range rules {
+		if tagCheck == "dive" {
+			rules, dive = rules[:i], rules[i+1:]
+			break
+		}
+	}
+	for _, tagCheck := range rules {
+		name, arg, hasArg := splitRule(tagCheck)
+		switch name {
+		case "required", "deprecated", "keys", "endkeys", "trim", "lower", "upper", "collapse":
+			switch {
+			case hasArg:
+				pass.Reportf(field.Tag.Pos(), "check %s does not accept an argument", name)
+			case name == "keys" || name == "endkeys":
+				pass.Reportf(field.Tag.Pos(), "bad syntax: %s must follow dive", name)
+			case name != "required" && name != "deprecated" && !isString(fieldType):
+				pass.Reportf(field.Tag.Pos(), "bad syntax: %s on %s", name, fieldType)
+			}
+			continue
+		case "unique":
+			checkUnique(pass, field, tagCheck, arg, hasArg, fieldType)
+			continue
+		case "expect", "iexpect", "call", "re", "!re", "match", "!match", "if", "group", "minlen":
+		default:
+			//rules of aliases are checked at run time
+			if _, ok := known["alias:"+tagCheck]; !ok {
+				pass.Reportf(field.Tag.Pos(), "unknown check: %s", tagCheck)
+			}
+			continue
+		}
+
+		if arg == "" {
+			pass.Reportf(field.Tag.Pos(), "bad syntax: %s requires an argument", name)
+			continue
+		}
+		switch name {
+		case "expect", "iexpect":
+			if strings.HasPrefix(arg, "@") {
+				checkValues(pass, field, tagCheck, arg[1:], parent, known)
+				continue
+			}
+			checkExpect(pass, field, arg, fieldType)
+		case "call":
+			checkCall(pass, field, arg, fieldType, parent, known)
+		case "re", "!re", "match", "!match":
+			if strings.HasPrefix(arg, "@@") {
+				arg = arg[1:]
+			} else if strings.HasPrefix(arg, "@") {
+				if _, ok := known["pattern:"+arg[1:]]; !ok {
+					pass.Reportf(field.Tag.Pos(), "pattern not found: %s", arg[1:])
+				}
+				continue
+			}
+			if _, err := regexp.Compile(arg); err != nil {
+				pass.Reportf(field.Tag.Pos(), "bad regular expression %q: %s", arg, err)
+			}
+		case "if":
+			checkCondition(pass, field, arg, parent)
+		case "minlen":
+			if n, err := strconv.Atoi(arg); err != nil || n < 0 || !hasLen(fieldType) {
+				pass.Reportf(field.Tag.Pos(), "bad syntax: %s", tagCheck)
+			}
+		}
+	}
+	if dive != nil {
+		checkDive(pass, field, dive, fieldType, parent, known)
+	}
+}
+
+func checkDive(pass *analysis.Pass, field *ast.Field, rules []string, fieldType types.Type, parent types.Type, known funcs) {
+	var keys []string
+	if len(rules) > 0 && rules[0] == "keys" {
+		end := -1
+		for i, tagCheck := range rules {
+			if tagCheck == "endkeys" {
+				end = i
+				break
+			}
+		}
+		if end < 0 {
+			pass.Reportf(field.Tag.Pos(), "bad syntax: keys without endkeys")
+			return
+		}
+		keys, rules = rules[1:end], rules[end+1:]
+	}
+	if ptr, ok := fieldType.Underlying().(*types.Pointer); ok {
+		fieldType = ptr.Elem()
+	}
+	var keyType, elemType types.Type
+	switch t := fieldType.Underlying().(type) {
+	case *types.Map:
+		keyType, elemType = t.Key(), t.Elem()
+	case *types.Slice:
+		elemType = t.Elem()
+	case *types.Array:
+		elemType = t.Elem()
+	default:
+		pass.Reportf(field.Tag.Pos(), "bad syntax: dive on %s", fieldType)
+		return
+	}
+	if keys != nil {
+		if keyType == nil {
+			pass.Reportf(field.Tag.Pos(), "bad syntax: keys on %s", fieldType)
+		} else if len(keys) > 0 {
+			for _, tagCheck := range keys {
+				switch tagCheck {
+				case "trim", "lower", "upper", "collapse":
+					pass.Reportf(field.Tag.Pos(), "bad syntax: %s on map keys", tagCheck)
+				}
+			}
+			checkRules(pass, field, keys, keyType, parent, known)
+		}
+	}
+	if len(rules) > 0 {
+		checkRules(pass, field, rules, elemType, parent, known)
+	}
+}
+
+//isString reports whether values of the type are normalized by rules
+func isString(t types.Type) bool {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+//checkUnique checks the unique rule of slices and arrays and the field of their
+//struct elements named by the argument. Fields of values held by interfaces are not known
+func checkUnique(pass *analysis.Pass, field *ast.Field, tagCheck string, arg string, hasArg bool, fieldType types.Type) {
+	if ptr, ok := fieldType.Underlying().(*types.Pointer); ok {
+		fieldType = ptr.Elem()
+	}
+	var elem types.Type
+	switch t := fieldType.Underlying().(type) {
+	case *types.Slice:
+		elem = t.Elem()
+	case *types.Array:
+		elem = t.Elem()
+	default:
+		pass.Reportf(field.Tag.Pos(), "bad syntax: unique on %s", fieldType)
+		return
+	}
+	if !hasArg {
+		return
+	}
+	if arg == "" {
+		pass.Reportf(field.Tag.Pos(), "bad syntax: %s", tagCheck)
+		return
+	}
+	if ptr, ok := elem.Underlying().(*types.Pointer); ok {
+		elem = ptr.Elem()
+	}
+	switch elem.Underlying().(type) {
+	case *types.Interface:
+	case *types.Struct:
+		obj, _, _ := types.LookupFieldOrMethod(elem, false, pass.Pkg, arg)
+		if v, ok := obj.(*types.Var); !ok || !v.IsField() || !v.Exported() {
+			pass.Reportf(field.Tag.Pos(), "field not found: %s", arg)
+		}
+	default:
+		pass.Reportf(field.Tag.Pos(), "bad syntax: %s on %s", tagCheck, fieldType)
+	}
+}
+
+//hasLen reports whether values of the type have the length
+func hasLen(t types.Type) bool {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	switch t := t.Underlying().(type) {
+	case *types.Basic:
+		return t.Info()&types.IsString != 0
+	case *types.Slice, *types.Array, *types.Map:
+		return true
+	}
+	return false
+}
+
+func splitRule(tagCheck string) (string, string, bool) {
+	idx := strings.Index(tagCheck, ":")
+	if idx < 0 {
+		return tagCheck, "", false
+	}
+	return tagCheck[:idx], tagCheck[idx+1:], true
+}
+
+func checkExpect(pass *analysis.Pass, field *ast.Field, arg string, fieldType types.Type) {
+	if ptr, ok := fieldType.Underlying().(*types.Pointer); ok {
+		fieldType = ptr.Elem()
+	}
+	basic, ok := fieldType.Underlying().(*types.Basic)
+	if !ok {
+		return
+	}
+	for _, value := range strings.Split(arg, ";") {
+		var err error
+		switch {
+		case basic.Info()&types.IsBoolean != 0:
+			_, err = strconv.ParseBool(value)
+		case basic.Info()&types.IsInteger != 0:
+			_, err = strconv.ParseInt(value, 10, 64)
+			if basic.Info()&types.IsUnsigned != 0 {
+				_, err = strconv.ParseUint(value, 10, 64)
+			}
+			if f, ferr := strconv.ParseFloat(value, 64); err != nil && ferr == nil && f == math.Trunc(f) &&
+				(f >= 0 || basic.Info()&types.IsUnsigned == 0) {
+				err = nil
+			}
+		case basic.Info()&types.IsFloat != 0:
+			_, err = strconv.ParseFloat(value, 64)
+		}
+		if err != nil {
+			pass.Reportf(field.Tag.Pos(), "expect value %q never matches %s", value, fieldType)
+		}
+	}
+}
+
+//checkValues checks the method of the parent or the registered function
+//returning values of expect:@Name
+func checkValues(pass *analysis.Pass, field *ast.Field, tagCheck string, name string, parent types.Type, known funcs) {
+	if name == "" {
+		pass.Reportf(field.Tag.Pos(), "bad syntax: %s", tagCheck)
+		return
+	}
+	obj, _, _ := types.LookupFieldOrMethod(parent, false, pass.Pkg, name)
+	if fn, ok := obj.(*types.Func); ok {
+		sig := fn.Type().(*types.Signature)
+		if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+			pass.Reportf(field.Tag.Pos(), "wrong signature method %s: want func() []T", name)
+		} else if _, ok := sig.Results().At(0).Type().Underlying().(*types.Slice); !ok {
+			pass.Reportf(field.Tag.Pos(), "wrong signature method %s: want func() []T", name)
+		}
+		return
+	}
+	if _, ok := known["@"+name]; !ok {
+		pass.Reportf(field.Tag.Pos(), "method not found: %s", name)
+	}
+}
+
+//checkCall checks the method of the call check. Methods of the parent are looked up first,
+//then methods of the type of the field and registered functions
+func checkCall(pass *analysis.Pass, field *ast.Field, arg string, fieldType types.Type, parent types.Type, known funcs) {
+	method, args, ok := parseCall(arg)
+	if !ok {
+		pass.Reportf(field.Tag.Pos(), "bad syntax: call:%s", arg)
+		return
+	}
+	owner := true
+	var sig *types.Signature
+	obj, _, _ := types.LookupFieldOrMethod(parent, true, pass.Pkg, method)
+	if fn, ok := obj.(*types.Func); ok {
+		sig = fn.Type().(*types.Signature)
+	} else if types.IsInterface(fieldType) {
+		//methods of the value held by the interface are not known
+		return
+	} else if obj, _, _ = types.LookupFieldOrMethod(fieldType, false, pass.Pkg, method); obj != nil {
+		fn, ok := obj.(*types.Func)
+		if !ok {
+			pass.Reportf(field.Tag.Pos(), "method not found: %s", method)
+			return
+		}
+		sig, owner = fn.Type().(*types.Signature), false
+	} else if sig, ok = known[method]; !ok {
+		pass.Reportf(field.Tag.Pos(), "method not found: %s", method)
+		return
+	} else if sig == nil {
+		//the signature of the function registered in the dependency is not known
+		return
+	}
+	params, ok := validSignature(sig, fieldType, owner, len(args))
+	if !ok {
+		want := "[string|checks.Field]"
+		if owner {
+			want = "[string|checks.Field,] " + fieldType.String()
+		}
+		for i := range args {
+			want += ", arg" + strconv.Itoa(i+1)
+		}
+		pass.Reportf(field.Tag.Pos(), "wrong signature method %s: want func([context.Context,] %s) error", method, want)
+		return
+	}
+	for i, arg := range args {
+		if !validArg(params[i], arg) {
+			pass.Reportf(field.Tag.Pos(), "bad argument %q of %s: want %s", arg, method, params[i])
+		}
+	}
+}
+
+//validSignature reports whether the method accepts [ctx context.Context] [name string | field checks.Field],
+//the value for methods of the parent and nargs arguments of basic types and returns error or []error.
+//Returns types of the parameters accepting the arguments
+func validSignature(sig *types.Signature, value types.Type, owner bool, nargs int) ([]types.Type, bool) {
+	params, results := sig.Params(), sig.Results()
+	if sig.Variadic() || results.Len() != 1 {
+		return nil, false
+	}
+	switch out := results.At(0).Type().(type) {
+	case *types.Slice:
+		if !types.Identical(out.Elem(), types.Universe.Lookup("error").Type()) {
+			return nil, false
+		}
+	default:
+		if !types.Implements(out, errorType) {
+			return nil, false
+		}
+	}
+	in := make([]types.Type, 0, params.Len())
+	for i := 0; i < params.Len(); i++ {
+		in = append(in, params.At(i).Type())
+	}
+	if len(in) > 0 && isNamed(in[0], "context", "Context") {
+		in = in[1:]
+	}
+	if len(in) < nargs {
+		return nil, false
+	}
+	args := in[len(in)-nargs:]
+	in = in[:len(in)-nargs]
+	for _, t := range args {
+		if basic, ok := t.Underlying().(*types.Basic); !ok || basic.Info()&(types.IsString|types.IsBoolean|types.IsInteger|types.IsFloat) == 0 ||
+			basic.Kind() == types.Uintptr {
+			return nil, false
+		}
+	}
+	if owner {
+		if len(in) == 0 || !types.AssignableTo(value, in[len(in)-1]) {
+			return nil, false
+		}
+		in = in[:len(in)-1]
+	}
+	switch {
+	case len(in) == 0:
+		return args, true
+	case len(in) > 1:
+		return nil, false
+	}
+	return args, isNamed(in[0], checksPath, "Field") || types.AssignableTo(types.Typ[types.String], in[0])
+}
+
+//validArg reports whether the argument of the call check is the value of the basic type
+func validArg(t types.Type, arg string) bool {
+	basic := t.Underlying().(*types.Basic)
+	info := basic.Info()
+	var err error
+	switch {
+	case info&types.IsString != 0:
+	case info&types.IsBoolean != 0:
+		_, err = strconv.ParseBool(arg)
+	case info&types.IsFloat != 0:
+		bits := 64
+		if basic.Kind() == types.Float32 {
+			bits = 32
+		}
+		_, err = strconv.ParseFloat(arg, bits)
+	case info&types.IsUnsigned != 0:
+		_, err = strconv.ParseUint(arg, 10, bitSize(basic))
+	default:
+		_, err = strconv.ParseInt(arg, 10, bitSize(basic))
+	}
+	return err == nil
+}
+
+func bitSize(basic *types.Basic) int {
+	switch basic.Kind() {
+	case types.Int8, types.Uint8:
+		return 8
+	case types.Int16, types.Uint16:
+		return 16
+	case types.Int32, types.Uint32:
+		return 32
+	case types.Int64, types.Uint64:
+		return 64
+	}
+	return strconv.IntSize
+}
+
+//parseCall parses the argument of the call rule: Name or Name(arg1,arg2)
+func parseCall(arg string) (string, []string, bool) {
+	idx := strings.Index(arg, "(")
+	if idx < 0 {
+		return arg, nil, true
+	}
+	if idx == 0 || !strings.HasSuffix(arg, ")") {
+		return "", nil, false
+	}
+	var args []string
+	if inner := strings.TrimSpace(arg[idx+1 : len(arg)-1]); inner != "" {
+		for _, a := range strings.Split(inner, ",") {
+			args = append(args, strings.TrimSpace(a))
+		}
+	}
+	return arg[:idx], args, true
+}
+
+func isNamed(t types.Type, pkg string, name string) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == pkg && named.Obj().Name() == name
+}
+
+func checkCondition(pass *analysis.Pass, field *ast.Field, arg string, parent types.Type) {
+	name := arg
+	if idx := strings.IndexAny(name, "!="); idx == 0 {
+		name = name[1:]
+	} else if idx > 0 {
+		name = name[:idx]
+	}
+	obj, _, _ := types.LookupFieldOrMethod(parent, true, pass.Pkg, name)
+	if v, ok := obj.(*types.Var); !ok || !v.IsField() {
+		pass.Reportf(field.Tag.Pos(), "field not found: %s", name)
+	}
+}
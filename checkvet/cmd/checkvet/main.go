@@ -0,0 +1,13 @@
+//Command checkvet reports mistakes in `check` tags.
+//
+//Usage:
+//
+//	go vet -vettool=$(which checkvet) ./...
+package main
+
+import (
+	"github.com/arteev/go-checks/checkvet"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() { singlechecker.Main(checkvet.Analyzer) }
@@ -0,0 +1,175 @@
+package a // want package:`registered\(Local, alias:name, pattern:name\)`
+
+import (
+	"context"
+	"errors"
+
+	_ "b"
+	"github.com/arteev/go-checks"
+)
+
+func init() {
+	checks.RegisterFunc("Local", func(field checks.Field, value string, prefix string) error { return nil })
+	checks.RegisterAlias("name", "required,match:@name")
+	checks.RegisterPattern("name", "[a-z]+")
+}
+
+type Level string
+
+type Listener struct {
+	Name   string
+	Port   int
+	secret string
+}
+
+//Copied shares the struct of Source but not its methods
+type Copied Source
+
+type Source struct {
+	Mode string `check:"expect:@Modes"`
+}
+
+func (s Source) Modes() []string {
+	return nil
+}
+
+type Config struct {
+	Listen   string   `check:"required"`
+	Typo     string   `check:"requierd"`     // want `unknown check: requierd`
+	Required string   `check:"required:yes"` // want `check required does not accept an argument`
+	LogLevel string   `check:"required,expect:info;debug;"`
+	Empty    string   `check:"expect:"` // want `bad syntax: expect requires an argument`
+	NotLast  string   `check:"expect:a;b,required"`
+	TwoArgs  string   `check:"re:[a-z]+,expect:a"`
+	Port     int      `check:"expect:80;443;http"` // want `expect value "http" never matches int`
+	PortPtr  *uint    `check:"expect:80;-1"`       // want `expect value "-1" never matches uint`
+	Ratio    float64  `check:"expect:0.5;1"`
+	Flag     bool     `check:"expect:true"`
+	Count    int      `check:"expect:1.0;2"`
+	Half     int      `check:"expect:1.5"` // want `expect value "1.5" never matches int`
+	Scheme   string   `check:"iexpect:HTTP;https"`
+	Upper    bool     `check:"iexpect:TRUE"`
+	NoValues string   `check:"iexpect:"` // want `bad syntax: iexpect requires an argument`
+	Regexp   string   `check:"re:[a-z]+"`
+	BadRe    string   `check:"re:[a-z"` // want `bad regular expression "\[a-z": error parsing regexp: missing closing \]: .*`
+	Value    string   `check:"call:ValueCheck"`
+	PtrValue string   `check:"call:PtrCheck"`
+	Missing  string   `check:"call:ValueChek"`  // want `method not found: ValueChek`
+	Wrong    int      `check:"call:ValueCheck"` // want `wrong signature method ValueCheck: want func\(\[context.Context,\] \[string\|checks.Field,\] int\) error`
+	Silent   string   `check:"call:NoResult"`   // want `wrong signature method NoResult: want func\(\[context.Context,\] \[string\|checks.Field,\] string\) error`
+	Context  string   `check:"call:ContextCheck"`
+	Short    string   `check:"call:ShortCheck"`
+	Level    Level    `check:"call:Valid"`
+	SetLevel Level    `check:"call:Set"` // want `method not found: Set`
+	Between  int      `check:"call:Range(1,10)"`
+	BadArg   int      `check:"call:Range(1,x)"` // want `bad argument "x" of Range: want int`
+	OneArg   int      `check:"call:Range(1)"`   // want `wrong signature method Range: want func\(\[context.Context,\] \[string\|checks.Field,\] int, arg1\) error`
+	OpenArgs int      `check:"call:Range(1,2"`  // want `bad syntax: call:Range\(1,2`
+	Prefix   string   `check:"call:Local(ab)"`
+	BadLocal int      `check:"call:Local(ab)"` // want `wrong signature method Local: want func\(\[context.Context,\] \[string\|checks.Field,\] int, arg1\) error`
+	Dep      string   `check:"call:Dep(1)"`
+	Env      string   `check:"trim,lower,expect:dev"`
+	Title    *string  `check:"collapse,upper"`
+	Trim     int      `check:"trim"`      // want `bad syntax: trim on int`
+	Lower    string   `check:"lower:yes"` // want `check lower does not accept an argument`
+	Aliases  []string `check:"dive,trim"`
+	BadLevel Level    `check:"call:Bad"` // want `wrong signature method Bad: want func\(\[context.Context,\] \[string\|checks.Field\]\) error`
+	TLS      bool
+	Mode     string
+	Cert     string `check:"required,if:TLS"`
+	Key      string `check:"required,if:!TLS,if:Mode=tls;mtls"`
+	CA       string `check:"required,if:Mode!=none"`
+	NoCond   string `check:"required,if:"` // want `bad syntax: if requires an argument`
+	ID       string `check:"group:update,required"`
+	NoGroup  string `check:"group:,required"`    // want `bad syntax: group requires an argument`
+	BadCond  string `check:"required,if:Enbled"` // want `field not found: Enbled`
+	NoTag    string
+	Other    string `json:"other"`
+
+	Tags     []string          `check:"required,minlen:1,dive,re:^[a-z]+$"`
+	Hosts    map[string]int    `check:"dive,keys,minlen:1,endkeys,expect:1;2"`
+	Matrix   [][]int           `check:"dive,minlen:1,dive,expect:0;1"`
+	Ports    []int             `check:"dive,expect:http"`           // want `expect value "http" never matches int`
+	DiveName string            `check:"dive,required"`              // want `bad syntax: dive on string`
+	KeysList []string          `check:"dive,keys,required,endkeys"` // want `bad syntax: keys on \[\]string`
+	OpenKeys map[string]string `check:"dive,keys,required"`         // want `bad syntax: keys without endkeys`
+	TrimKeys map[string]string `check:"dive,keys,trim,endkeys"`     // want `bad syntax: trim on map keys`
+	Extra    string            `check:"required,endkeys"`           // want `bad syntax: endkeys must follow dive`
+	BadLen   int               `check:"minlen:1"`                   // want `bad syntax: minlen:1`
+	NegLen   []string          `check:"minlen:-1"`                  // want `bad syntax: minlen:-1`
+
+	Driver      string `check:"expect:@Drivers"`
+	Plugin      string `check:"iexpect:@AllowedPlugins"`
+	MissingVals string `check:"expect:@Missing"` // want `method not found: Missing`
+	BadValues   string `check:"expect:@Range"`   // want `wrong signature method Range: want func\(\) \[\]T`
+	EmptyValues string `check:"expect:@"`        // want `bad syntax: expect:@`
+
+	Names      []string      `check:"unique"`
+	Listeners  []*Listener   `check:"unique:Name,unique:Port"`
+	Endpoints  []interface{} `check:"unique:Name"`
+	Matrix2    [][]int       `check:"dive,unique"`
+	OneName    string        `check:"unique"`         // want `bad syntax: unique on string`
+	NoName     []Listener    `check:"unique:Missing"` // want `field not found: Missing`
+	Hidden     []Listener    `check:"unique:secret"`  // want `field not found: secret`
+	NameOfInts []int         `check:"unique:Name"`    // want `bad syntax: unique:Name on \[\]int`
+	EmptyName  []Listener    `check:"unique:"`        // want `bad syntax: unique:`
+
+	NoDigits string `check:"!re:[0-9]"`
+	Slug     string `check:"match:[a-z]+"`
+	NotRoot  string `check:"!match:root"`
+	BadMatch string `check:"!match:[a-z"` // want `bad regular expression "\[a-z": error parsing regexp: missing closing \]: .*`
+	NoMatch  string `check:"match:"`      // want `bad syntax: match requires an argument`
+
+	Service   string `check:"name"`
+	HTTPPort  int    `check:"port"`
+	Version   string `check:"match:@semver"`
+	NoPattern string `check:"!re:@missing"` // want `pattern not found: missing`
+	Mention   string `check:"match:@@[a-z]+"`
+	BadAt     string `check:"re:@@[a-z"` // want `bad regular expression "@\[a-z": .*`
+	NoAlias   string `check:"names"`     // want `unknown check: names`
+}
+
+func (c Config) ValueCheck(name string, value string) error {
+	return nil
+}
+
+func (c *Config) PtrCheck(name string, value string) error {
+	return errors.New(name)
+}
+
+func (c Config) NoResult(name string, value string) {}
+
+func (c Config) ContextCheck(ctx context.Context, field checks.Field, value string) []error {
+	return nil
+}
+
+func (c Config) ShortCheck(value string) error {
+	return nil
+}
+
+func (c Config) Range(name string, value int, min, max int) error {
+	return nil
+}
+
+func (c Config) AllowedPlugins() []string {
+	return nil
+}
+
+func (l Level) Valid(name string) error {
+	return nil
+}
+
+func (l *Level) Set() error {
+	return nil
+}
+
+func (l Level) Bad(value Level) error {
+	return nil
+}
+
+func anonymous() interface{} {
+	return struct {
+		Value string `check:"call:Method"` // want `method not found: Method`
+		Re    string `check:"re:("`        // want `bad regular expression.*`
+	}{}
+}
@@ -0,0 +1,10 @@
+package b // want package:`registered\(Dep, @Drivers, alias:port, pattern:semver\)`
+
+import "github.com/arteev/go-checks"
+
+func init() {
+	checks.RegisterFunc("Dep", func(value string, min int) error { return nil })
+	checks.RegisterValues("Drivers", func() []string { return nil })
+	checks.RegisterAlias("port", "required,expect:80;443")
+	checks.RegisterPattern("semver", `[0-9]+\.[0-9]+\.[0-9]+`)
+}
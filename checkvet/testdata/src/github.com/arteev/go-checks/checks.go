@@ -0,0 +1,16 @@
+package checks
+
+type Field struct {
+	Name string
+	Path string
+}
+
+func RegisterFunc(name string, fn interface{}) {}
+
+type ValuesFunc func() []string
+
+func RegisterValues(name string, fn ValuesFunc) {}
+
+func RegisterAlias(name string, rules string) {}
+
+func RegisterPattern(name string, expr string) {}
@@ -0,0 +1,1365 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"math"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//Kinds of the types known to the generator
+const (
+	kindUnknown kind = iota
+	kindString
+	kindNumber
+	kindBool
+	kindPtr
+	kindSlice
+	kindArray
+	kindMap
+	kindInterface
+	kindFunc
+	kindChan
+	kindStruct
+)
+
+type (
+	kind int
+
+	//typeInfo describes type expression of a field or an element
+	typeInfo struct {
+		kind  kind
+		named bool
+		name  string //name of struct type declared in the package
+		key   ast.Expr
+		elem  ast.Expr
+	}
+
+	generator struct {
+		pkg      string
+		types    map[string]*ast.TypeSpec
+		methods  map[string]map[string]*ast.FuncType
+		pointer  map[string]map[string]bool //methods with pointer receivers
+		aliases  map[string][]string        //aliases registered in the package
+		patterns map[string]string          //regular expressions registered in the package
+		imports  map[string]struct{}
+
+		buf     bytes.Buffer
+		vars    bytes.Buffer
+		nvars   int
+		nkeys   int
+		done    map[string]bool
+		queue   []string
+		nesting int
+	}
+)
+
+var numbers = map[string]struct{}{
+	"int": {}, "int8": {}, "int16": {}, "int32": {}, "int64": {},
+	"uint": {}, "uint8": {}, "uint16": {}, "uint32": {}, "uint64": {}, "uintptr": {},
+	"float32": {}, "float64": {}, "complex64": {}, "complex128": {},
+	"byte": {}, "rune": {},
+}
+
+func generate(dir string, typeNames []string, skipFile string) ([]byte, error) {
+	g, err := parsePackage(dir, skipFile)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range typeNames {
+		name = strings.TrimSpace(name)
+		spec, ok := g.types[name]
+		if !ok {
+			return nil, fmt.Errorf("type not found: %s", name)
+		}
+		if _, ok := spec.Type.(*ast.StructType); !ok {
+			return nil, fmt.Errorf("type is not a struct: %s", name)
+		}
+		g.enqueue(name)
+	}
+	for len(g.queue) > 0 {
+		name := g.queue[0]
+		g.queue = g.queue[1:]
+		if err := g.genStruct(name); err != nil {
+			return nil, err
+		}
+	}
+	g.genRegister(typeNames)
+	return g.source()
+}
+
+func parsePackage(dir string, skipFile string) (*generator, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		types:    make(map[string]*ast.TypeSpec),
+		methods:  make(map[string]map[string]*ast.FuncType),
+		pointer:  make(map[string]map[string]bool),
+		aliases:  make(map[string][]string),
+		patterns: make(map[string]string),
+		imports:  make(map[string]struct{}),
+		done:     make(map[string]bool),
+	}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") || filepath.Base(name) == skipFile {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		if g.pkg == "" {
+			g.pkg = file.Name.Name
+		}
+		g.collect(file)
+	}
+	if g.pkg == "" {
+		return nil, fmt.Errorf("no go files in %s", dir)
+	}
+	return g, nil
+}
+
+func (g *generator) collect(file *ast.File) {
+	g.collectRegistered(file)
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.TypeSpec)
+				g.types[spec.Name.Name] = spec
+			}
+		case *ast.FuncDecl:
+			if decl.Recv == nil || len(decl.Recv.List) != 1 {
+				continue
+			}
+			recv := decl.Recv.List[0].Type
+			star, pointer := recv.(*ast.StarExpr)
+			if pointer {
+				recv = star.X
+			}
+			ident, ok := recv.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if g.methods[ident.Name] == nil {
+				g.methods[ident.Name] = make(map[string]*ast.FuncType)
+				g.pointer[ident.Name] = make(map[string]bool)
+			}
+			g.methods[ident.Name][decl.Name.Name] = decl.Type
+			g.pointer[ident.Name][decl.Name.Name] = pointer
+		}
+	}
+}
+
+//collectRegistered collects aliases and regular expressions registered in the package
+//by calls of checks.RegisterAlias and checks.RegisterPattern with constant arguments
+func (g *generator) collectRegistered(file *ast.File) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+		name, ok := stringLiteral(call.Args[0])
+		value, valueOK := stringLiteral(call.Args[1])
+		switch {
+		case !ok || !valueOK:
+		case isSelector(call.Fun, "checks", "RegisterAlias"):
+			g.aliases[name] = splitTag(value)
+		case isSelector(call.Fun, "checks", "RegisterPattern"):
+			g.patterns[name] = value
+		}
+		return true
+	})
+}
+
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+//expand replaces aliases registered in the package with their rules.
+//path is the list of aliases being expanded
+func (g *generator) expand(rules []string, path []string) ([]string, error) {
+	result := make([]string, 0, len(rules))
+	for _, r := range rules {
+		expanded, ok := g.aliases[r]
+		if !ok {
+			result = append(result, r)
+			continue
+		}
+		for _, alias := range path {
+			if alias == r {
+				return nil, fmt.Errorf("alias cycle: %s", strings.Join(append(path, r), " -> "))
+			}
+		}
+		expanded, err := g.expand(expanded, append(path[:len(path):len(path)], r))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, expanded...)
+	}
+	return result, nil
+}
+
+func (g *generator) enqueue(name string) {
+	if g.done[name] {
+		return
+	}
+	g.done[name] = true
+	g.queue = append(g.queue, name)
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) source() ([]byte, error) {
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by checksgen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", g.pkg)
+	imports := make([]string, 0, len(g.imports))
+	for imp := range g.imports {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+	fmt.Fprintf(&src, "import (\n")
+	for _, imp := range imports {
+		fmt.Fprintf(&src, "\t%q\n", imp)
+	}
+	if len(imports) > 0 {
+		fmt.Fprintf(&src, "\n")
+	}
+	fmt.Fprintf(&src, "\t%q\n)\n\n", "github.com/arteev/go-checks")
+	if g.vars.Len() > 0 {
+		fmt.Fprintf(&src, "var (\n%s)\n\n", g.vars.String())
+	}
+	src.Write(g.buf.Bytes())
+	result, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("internal error: invalid generated code: %s", err)
+	}
+	return result, nil
+}
+
+func (g *generator) genRegister(typeNames []string) {
+	g.printf("func init() {\n")
+	for _, name := range typeNames {
+		name = strings.TrimSpace(name)
+		g.printf("checks.Register((*%s)(nil), func(c *checks.Collector, v interface{}) bool {\n", name)
+		g.printf("if !c.Add(checks.Append(nil, checks.CallChecker(v))...) {\nreturn false\n}\n")
+		g.printf("return c.Skipped() || _checks_%s(c, v.(*%s))\n", name, name)
+		g.printf("})\n")
+		g.printf("checks.Register(%s{}, func(c *checks.Collector, v interface{}) bool {\n", name)
+		g.printf("if !c.Add(checks.Append(nil, checks.CallChecker(v))...) {\nreturn false\n}\n")
+		g.printf("if c.Skipped() {\nreturn true\n}\n")
+		g.printf("value := v.(%s)\n", name)
+		g.printf("return _checks_%s(c, &value)\n", name)
+		g.printf("})\n")
+	}
+	g.printf("}\n")
+}
+
+func (g *generator) genStruct(name string) error {
+	st := g.types[name].Type.(*ast.StructType)
+	body, err := g.capture(func() error {
+		return g.genFields(name, st)
+	})
+	if err != nil {
+		return err
+	}
+	g.printf("func _checks_%s(c *checks.Collector, v *%s) bool {\n", name, name)
+	if strings.Contains(body, "err = ") {
+		g.printf("var err error\n")
+	}
+	if strings.Contains(body, "errs = ") {
+		g.printf("var errs []error\n")
+	}
+	g.printf("%sreturn true\n}\n\n", body)
+	return nil
+}
+
+//capture returns code written by fn
+func (g *generator) capture(fn func() error) (string, error) {
+	saved := g.buf
+	g.buf = bytes.Buffer{}
+	err := fn()
+	body := g.buf.String()
+	g.buf = saved
+	return body, err
+}
+
+func (g *generator) genFields(name string, st *ast.StructType) error {
+	for _, field := range st.Fields.List {
+		names := make([]string, 0, len(field.Names))
+		for _, ident := range field.Names {
+			names = append(names, ident.Name)
+		}
+		if len(names) == 0 {
+			names = append(names, embeddedName(field.Type))
+		}
+		tag := ""
+		if field.Tag != nil {
+			s, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return err
+			}
+			tag = s
+		}
+		for _, fieldName := range names {
+			f := &fieldNode{
+				name:     fieldName,
+				tag:      reflect.StructTag(tag),
+				parent:   name,
+				promoted: len(field.Names) == 0 && (g.isStruct(field.Type) || g.isImported(field.Type)),
+			}
+			body, err := g.capture(func() error {
+				return g.genNode(field.Type, "v."+fieldName, f)
+			})
+			if err != nil {
+				return fmt.Errorf("%s.%s: %s", name, fieldName, err)
+			}
+			switch {
+			case body == "":
+			case f.promoted:
+				g.printf("%s", body)
+			case ast.IsExported(fieldName):
+				g.printf("c.EnterField(%q)\n%sc.Leave()\n", fieldName, body)
+			default:
+				g.genUnexported(f, body)
+			}
+		}
+	}
+	return nil
+}
+
+type fieldNode struct {
+	name     string
+	tag      reflect.StructTag
+	parent   string
+	promoted bool //fields of the embedded struct are promoted, see Collector.Embedded for imported types
+}
+
+//genUnexported writes checks of the unexported field according to the policy of the collector
+func (g *generator) genUnexported(field *fieldNode, body string) {
+	g.printf("switch c.Unexported() {\ncase checks.UnexportedCheck:\n")
+	g.printf("c.EnterField(%q)\n%sc.Leave()\n", field.name, body)
+	if _, ok := field.tag.Lookup("check"); ok {
+		g.printf("case checks.UnexportedError:\nc.EnterField(%q)\n", field.name)
+		g.printf("if !c.Add(checks.NewError(checks.ErrUnexported, %q, nil, checks.ErrorType)) {\nreturn false\n}\n", field.name)
+		g.printf("c.Leave()\n")
+	}
+	g.printf("}\n")
+}
+
+//isStruct reports whether the type is the struct or the pointer to the struct declared in the package
+func (g *generator) isStruct(expr ast.Expr) bool {
+	info, err := g.typeOf(expr)
+	if err == nil && info.kind == kindPtr {
+		info, err = g.typeOf(info.elem)
+	}
+	return err == nil && info.kind == kindStruct
+}
+
+//isImported reports whether the type is the type or the pointer to the type declared in other package
+func (g *generator) isImported(expr ast.Expr) bool {
+	info, err := g.typeOf(expr)
+	if err == nil && info.kind == kindPtr {
+		info, err = g.typeOf(info.elem)
+	}
+	return err == nil && info.kind == kindUnknown
+}
+
+func embeddedName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(expr.X)
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.Ident:
+		return expr.Name
+	}
+	return ""
+}
+
+func (g *generator) typeOf(expr ast.Expr) (typeInfo, error) {
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return g.typeOf(expr.X)
+	case *ast.Ident:
+		if spec, ok := g.types[expr.Name]; ok {
+			if _, ok := spec.Type.(*ast.StructType); ok {
+				return typeInfo{kind: kindStruct, named: true, name: expr.Name}, nil
+			}
+			info, err := g.typeOf(spec.Type)
+			info.named = true
+			return info, err
+		}
+		if _, ok := numbers[expr.Name]; ok {
+			return typeInfo{kind: kindNumber}, nil
+		}
+		switch expr.Name {
+		case "string":
+			return typeInfo{kind: kindString}, nil
+		case "bool":
+			return typeInfo{kind: kindBool}, nil
+		case "error":
+			return typeInfo{kind: kindInterface, named: true}, nil
+		}
+		return typeInfo{}, fmt.Errorf("unknown type: %s", expr.Name)
+	case *ast.SelectorExpr:
+		return typeInfo{kind: kindUnknown, named: true}, nil
+	case *ast.StarExpr:
+		return typeInfo{kind: kindPtr, elem: expr.X}, nil
+	case *ast.ArrayType:
+		if expr.Len == nil {
+			return typeInfo{kind: kindSlice, elem: expr.Elt}, nil
+		}
+		return typeInfo{kind: kindArray, elem: expr.Elt}, nil
+	case *ast.MapType:
+		return typeInfo{kind: kindMap, key: expr.Key, elem: expr.Value}, nil
+	case *ast.InterfaceType:
+		return typeInfo{kind: kindInterface}, nil
+	case *ast.FuncType:
+		return typeInfo{kind: kindFunc}, nil
+	case *ast.ChanType:
+		return typeInfo{kind: kindChan}, nil
+	case *ast.StructType:
+		return typeInfo{}, fmt.Errorf("anonymous struct types are not supported")
+	}
+	return typeInfo{}, fmt.Errorf("unsupported type %T", expr)
+}
+
+//mayCheck reports whether values of the type may implement checks.Checker
+func (t typeInfo) mayCheck() bool {
+	switch t.kind {
+	case kindPtr, kindInterface, kindStruct, kindUnknown:
+		return true
+	}
+	return t.named
+}
+
+func (t typeInfo) nilable() bool {
+	switch t.kind {
+	case kindPtr, kindInterface, kindFunc, kindChan:
+		return true
+	}
+	return false
+}
+
+//genNode writes checks of the single value: checks.Checker and rules of the tag,
+//then checks of the nested values
+func (g *generator) genNode(expr ast.Expr, access string, field *fieldNode) error {
+	info, err := g.typeOf(expr)
+	if err != nil {
+		return err
+	}
+	var rules []string
+	if field != nil {
+		if rules, err = g.rules(expr, info, access, field); err != nil {
+			return err
+		}
+	}
+	checker := info.mayCheck()
+	promoted := field != nil && field.promoted
+	if info.kind == kindInterface {
+		//values held by interfaces are normalized before their checks like the iterator does
+		g.printf("if %s != nil {\nc.Normalize(%s)\n}\n", access, access)
+	}
+	if checker || len(rules) > 0 {
+		if promoted {
+			g.printf("c.EnterField(%q)\n", field.name)
+		}
+		g.printf("errs = errs[:0]\n")
+		if checker {
+			if info.nilable() {
+				g.printf("err = nil\nif %s != nil {\nerr = checks.CallChecker(%s)\n}\n", access, access)
+			} else {
+				g.printf("err = checks.CallChecker(%s)\n", access)
+			}
+			g.printf("if err != nil {\nerrs = append(errs, err)\n}")
+			if len(rules) > 0 {
+				g.printf(" else {\n%s}", strings.Join(rules, ""))
+			}
+			g.printf("\n")
+		} else {
+			g.printf("%s", strings.Join(rules, ""))
+		}
+		g.printf("if !c.Add(errs...) {\nreturn false\n}\n")
+		if promoted {
+			g.printf("c.Leave()\n")
+		}
+	}
+	keyField, elemField, err := g.diveFields(info, field)
+	if err != nil {
+		return err
+	}
+	children, err := g.capture(func() error {
+		if promoted && g.isImported(expr) {
+			//fields are promoted if the type is the struct known at run time
+			if info.kind == kindPtr {
+				g.printf("if %s != nil && !c.Embedded(%q, %s) {\nreturn false\n}\n", access, field.name, access)
+			} else {
+				g.printf("if !c.Embedded(%q, &%s) {\nreturn false\n}\n", field.name, access)
+			}
+			return nil
+		}
+		return g.genChildren(info, access, keyField, elemField)
+	})
+	if err != nil || children == "" {
+		return err
+	}
+	if checker || len(rules) > 0 {
+		g.printf("if !c.Skipped() {\n%s}\n", children)
+	} else {
+		g.printf("%s", children)
+	}
+	return nil
+}
+
+//genChildren writes checks of the nested values. Map keys and elements are
+//described by keyField and elemField of the dive rule
+func (g *generator) genChildren(info typeInfo, access string, keyField, elemField *fieldNode) error {
+	switch info.kind {
+	case kindStruct:
+		g.enqueue(info.name)
+		g.printf("if !_checks_%s(c, &%s) {\nreturn false\n}\n", info.name, access)
+	case kindPtr:
+		elem, err := g.typeOf(info.elem)
+		if err != nil {
+			return err
+		}
+		switch elem.kind {
+		case kindStruct:
+			g.enqueue(elem.name)
+			g.printf("if %s != nil && !_checks_%s(c, %s) {\nreturn false\n}\n", access, elem.name, access)
+		case kindUnknown:
+			g.printf("if %s != nil && !c.Nested(%s) {\nreturn false\n}\n", access, access)
+		}
+	case kindUnknown:
+		//types declared in other packages are checked using reflection
+		g.printf("if !c.Nested(&%s) {\nreturn false\n}\n", access)
+	case kindInterface:
+		g.printf("if %s != nil && !c.Nested(%s) {\nreturn false\n}\n", access, access)
+	case kindSlice, kindArray:
+		idx := fmt.Sprintf("i%d", g.nesting)
+		g.nesting++
+		defer func() { g.nesting-- }()
+		body, err := g.capture(func() error {
+			return g.genNode(info.elem, access+"["+idx+"]", elemField)
+		})
+		if err != nil || body == "" {
+			return err
+		}
+		g.printf("for %s := range %s {\nc.EnterIndex(%s)\n%sc.Leave()\n}\n", idx, access, idx, body)
+	case kindMap:
+		//slices of keys are declared in the scope of the struct, names must be unique
+		keys := fmt.Sprintf("keys%d", g.nkeys)
+		g.nkeys++
+		key, elem := fmt.Sprintf("k%d", g.nesting), fmt.Sprintf("e%d", g.nesting)
+		g.nesting++
+		defer func() { g.nesting-- }()
+		body, err := g.capture(func() error {
+			if keyField != nil {
+				if err := g.genNode(info.key, key, keyField); err != nil {
+					return err
+				}
+			}
+			return g.genNode(info.elem, elem, elemField)
+		})
+		if err != nil || body == "" {
+			return err
+		}
+		keyType, err := g.typeSource(info.key)
+		if err != nil {
+			return err
+		}
+		key0, key1 := keys+"[i]", keys+"[j]"
+		less := fmt.Sprintf("checks.Less(%s, %s)", key0, key1)
+		if keyInfo, err := g.typeOf(info.key); err == nil && keyInfo.kind == kindString {
+			less = key0 + " < " + key1
+		}
+		g.imports["sort"] = struct{}{}
+		g.printf("%s := make([]%s, 0, len(%s))\n", keys, keyType, access)
+		g.printf("for %s := range %s {\n%s = append(%s, %s)\n}\n", key, access, keys, keys, key)
+		g.printf("sort.Slice(%s, func(i, j int) bool {\nreturn %s\n})\n", keys, less)
+		g.printf("for _, %s := range %s {\n", key, keys)
+		if regexp.MustCompile(`\b` + elem + `\b`).MatchString(body) {
+			g.printf("%s := %s[%s]\n", elem, access, key)
+		}
+		g.printf("c.EnterKey(%s)\n%sc.Leave()\n}\n", key, body)
+	}
+	return nil
+}
+
+//typeSource returns the source of the type expression declared in the package
+func (g *generator) typeSource(expr ast.Expr) (string, error) {
+	var imported bool
+	ast.Inspect(expr, func(n ast.Node) bool {
+		_, ok := n.(*ast.SelectorExpr)
+		imported = imported || ok
+		return !imported
+	})
+	if imported {
+		return "", fmt.Errorf("map keys of imported types are not supported")
+	}
+	var b bytes.Buffer
+	if err := format.Node(&b, token.NewFileSet(), expr); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func (g *generator) zero(info typeInfo, access string) string {
+	switch info.kind {
+	case kindString:
+		return access + ` == ""`
+	case kindNumber:
+		return access + " == 0"
+	case kindBool:
+		return "false"
+	case kindPtr, kindInterface, kindFunc, kindChan:
+		return access + " == nil"
+	case kindSlice, kindMap:
+		return "len(" + access + ") == 0"
+	}
+	return "checks.IsZero(" + access + ")"
+}
+
+func (g *generator) addVar(prefix string, value string) string {
+	name := fmt.Sprintf("_checks_%s_%d", prefix, g.nvars)
+	g.nvars++
+	fmt.Fprintf(&g.vars, "%s = %s\n", name, value)
+	return name
+}
+
+//rules returns statements appending errors of the rules of the tag
+func (g *generator) rules(expr ast.Expr, info typeInfo, access string, field *fieldNode) ([]string, error) {
+	enum, err := g.enum(expr, access, field)
+	if err != nil {
+		return nil, err
+	}
+	sTag, ok := field.tag.Lookup("check")
+	if !ok {
+		if enum == "" {
+			return nil, nil
+		}
+		return []string{enum}, nil
+	}
+	name := strconv.Quote(field.name)
+	unexpectedNil := fmt.Sprintf("errs = append(errs, checks.NewError(checks.ErrValueUnexpected, %s, \"<nil>\", checks.ErrorType))\n",
+		name)
+
+	tagRules, err := g.expand(splitTag(sTag), nil)
+	if err != nil {
+		return nil, err
+	}
+	rules, keys, _, dive, ok := splitDive(tagRules)
+	syntax, err := g.diveSyntax(info, keys, dive, ok)
+	if err != nil {
+		return nil, err
+	}
+	if syntax != "" {
+		return []string{fmt.Sprintf("errs = append(errs, checks.NewError(checks.ErrBadSyntax, %s, %q, checks.ErrorType))\n",
+			name, syntax)}, nil
+	}
+
+	var (
+		result []string
+		conds  []string
+		groups []string
+	)
+	if enum != "" && !hasExpect(rules) {
+		result = append(result, enum)
+	}
+	for _, tagCheck := range rules {
+		ruleName, arg := tagCheck, ""
+		if idx := strings.Index(tagCheck, ":"); idx >= 0 {
+			ruleName, arg = tagCheck[:idx], tagCheck[idx+1:]
+		}
+		quotedRule := strconv.Quote(tagCheck)
+		if ruleName == "group" {
+			groups = append(groups, arg)
+			continue
+		}
+		if ruleName == "if" {
+			cond, invalid, err := g.condition(arg, field)
+			if err != nil {
+				return nil, err
+			}
+			if invalid != "" {
+				cond = fmt.Sprintf("errs = append(errs, checks.NewError(%s, %s, %s, checks.ErrorType))\n",
+					invalid, name, quotedRule)
+			}
+			conds = append(conds, cond)
+			continue
+		}
+		switch ruleName {
+		case "expect", "iexpect", "call", "re", "!re", "match", "!match", "minlen":
+			if arg == "" {
+				result = append(result, fmt.Sprintf("errs = append(errs, checks.NewError(checks.ErrBadSyntax, %s, %s, checks.ErrorType))\n",
+					name, quotedRule))
+				continue
+			}
+		}
+		switch {
+		case tagCheck == "required":
+			result = append(result, fmt.Sprintf("errs = checks.Append(errs, checks.Required(%s, %s))\n",
+				name, g.zero(info, access)))
+		case tagCheck == "deprecated":
+			result = append(result, fmt.Sprintf("errs = checks.Append(errs, checks.Deprecated(%s, %s))\n",
+				name, g.zero(info, access)))
+		case ruleName == "expect", ruleName == "iexpect":
+			if arg == "@" {
+				result = append(result, fmt.Sprintf("errs = append(errs, checks.NewError(checks.ErrBadSyntax, %s, %s, checks.ErrorType))\n",
+					name, quotedRule))
+				continue
+			}
+			value := access
+			if info.kind == kindPtr {
+				value = "*" + access
+			}
+			var expect string
+			if strings.HasPrefix(arg, "@") {
+				//values are returned by the method of the struct or the registered function at run time
+				expect = fmt.Sprintf("errs = checks.Append(errs, c.ExpectFrom(%s, %s, v, %s))\n", name, quotedRule, value)
+			} else {
+				values := g.addVar("values", fmt.Sprintf("%#v", strings.Split(arg, ";")))
+				helper := "Expect"
+				if ruleName == "iexpect" {
+					helper = "IExpect"
+				}
+				expect = fmt.Sprintf("errs = checks.Append(errs, checks.%s(%s, %s, %s))\n", helper, name, value, values)
+			}
+			if info.nilable() {
+				expect = fmt.Sprintf("if %s == nil {\n%s} else {\n%s}\n", access, unexpectedNil, expect)
+			}
+			result = append(result, expect)
+		case tagCheck == "trim", tagCheck == "lower", tagCheck == "upper", tagCheck == "collapse":
+			//values are normalized before checks
+			if info.kind == kindUnknown {
+				return nil, fmt.Errorf("%s is not supported for imported types", tagCheck)
+			}
+			if !g.isString(info) {
+				result = append(result, fmt.Sprintf("errs = append(errs, checks.NewError(checks.ErrBadSyntax, %s, %s, checks.ErrorType))\n",
+					name, quotedRule))
+			}
+		case tagCheck == "keys", tagCheck == "endkeys":
+			result = append(result, fmt.Sprintf("errs = append(errs, checks.NewError(checks.ErrBadSyntax, %s, %s, checks.ErrorType))\n",
+				name, quotedRule))
+		case ruleName == "unique":
+			//fields of elements are looked up at run time
+			switch info.kind {
+			case kindSlice, kindArray, kindPtr, kindUnknown:
+				result = append(result, fmt.Sprintf("errs = checks.Append(errs, checks.Unique(%s, %s, %s)...)\n",
+					name, quotedRule, access))
+			default:
+				result = append(result, fmt.Sprintf("errs = append(errs, checks.NewError(checks.ErrBadSyntax, %s, %s, checks.ErrorType))\n",
+					name, quotedRule))
+			}
+		case ruleName == "minlen":
+			minlen, err := g.minlen(info, arg, tagCheck, access, field)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, minlen)
+		case ruleName == "call":
+			call, err := g.call(arg, tagCheck, expr, access, field)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, call)
+		case ruleName == "re", ruleName == "!re", ruleName == "match", ruleName == "!match":
+			result = append(result, g.match(info, ruleName, arg, tagCheck, access, field))
+		default:
+			//aliases registered outside the package are not known
+			return nil, fmt.Errorf("unknown check: %s", tagCheck)
+		}
+	}
+
+	//rules are checked if all conditions hold, the invalid condition is the result
+	body := strings.Join(result, "")
+	for i := len(conds) - 1; i >= 0; i-- {
+		switch {
+		case strings.HasPrefix(conds[i], "errs = "):
+			body = conds[i]
+		case body != "":
+			body = fmt.Sprintf("if %s {\n%s}\n", conds[i], body)
+		}
+	}
+	return g.groups(groups, name, body), nil
+}
+
+//enum returns the statement checking the value of the enumeration type: the type declared
+//in the package with the method Values or Valid of the value receiver. Values of imported
+//types and interfaces are checked at run time
+func (g *generator) enum(expr ast.Expr, access string, field *fieldNode) (string, error) {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	info, err := g.typeOf(expr)
+	if err != nil {
+		return "", err
+	}
+	stmt := fmt.Sprintf("errs = checks.Append(errs, checks.Enum(%q, %s))\n", field.name, access)
+	switch info.kind {
+	case kindUnknown, kindInterface:
+		return stmt, nil
+	}
+	typeName, _ := localType(expr)
+	for _, method := range []string{"Values", "Valid"} {
+		fn, ok := g.methods[typeName][method]
+		if ok && !g.pointer[typeName][method] && fn.Params.NumFields() == 0 && fn.Results.NumFields() == 1 {
+			return stmt, nil
+		}
+	}
+	return "", nil
+}
+
+//hasExpect reports whether the values of the field are listed by rules,
+//listed values replace the values of the enumeration
+func hasExpect(rules []string) bool {
+	for _, r := range rules {
+		if r == "expect" || r == "iexpect" || strings.HasPrefix(r, "expect:") || strings.HasPrefix(r, "iexpect:") {
+			return true
+		}
+	}
+	return false
+}
+
+//match returns statements matching the value with the regular expression of the rule.
+//Regular expressions of match rules match the entire value, nil values match nothing
+func (g *generator) match(info typeInfo, ruleName string, arg string, tagCheck string, access string, field *fieldNode) string {
+	name, quotedRule := strconv.Quote(field.name), strconv.Quote(tagCheck)
+	negated := strings.HasPrefix(ruleName, "!")
+	switch {
+	case strings.HasPrefix(arg, "@@"):
+		arg = arg[1:]
+	case strings.HasPrefix(arg, "@"):
+		if expr, ok := g.patterns[arg[1:]]; ok {
+			arg = expr
+		} else {
+			//the regular expression is registered outside the package
+			return nilMatch(info, negated, name, fmt.Sprintf("errs = checks.Append(errs, checks.MatchRule(%s, %s, %s))\n",
+				name, quotedRule, access), access)
+		}
+	}
+	expr := arg
+	if ruleName == "match" || ruleName == "!match" {
+		expr = "^(?:" + arg + ")$"
+	}
+	var match string
+	//errors are reported for the expression of the tag
+	if _, err := regexp.Compile(arg); err != nil {
+		g.imports["errors"] = struct{}{}
+		match = fmt.Sprintf("errs = append(errs, checks.NewError(errors.New(%q), %s, %s, checks.ErrorType))\n",
+			err.Error(), name, quotedRule)
+	} else {
+		re := g.addVar("re", fmt.Sprintf("regexp.MustCompile(%s)", strconv.Quote(expr)))
+		g.imports["regexp"] = struct{}{}
+		helper := "Match"
+		if negated {
+			helper = "NotMatch"
+		}
+		match = fmt.Sprintf("errs = checks.Append(errs, checks.%s(%s, %s, %s, %s))\n",
+			helper, name, quotedRule, re, access)
+	}
+	return nilMatch(info, negated, name, match, access)
+}
+
+//nilMatch returns the statement matching nilable values: nil values match nothing
+func nilMatch(info typeInfo, negated bool, name string, match string, access string) string {
+	if !info.nilable() {
+		return match
+	}
+	if negated {
+		return fmt.Sprintf("if %s != nil {\n%s}\n", access, match)
+	}
+	unexpectedNil := fmt.Sprintf("errs = append(errs, checks.NewError(checks.ErrValueUnexpected, %s, \"<nil>\", checks.ErrorType))\n",
+		name)
+	return fmt.Sprintf("if %s == nil {\n%s} else {\n%s}\n", access, unexpectedNil, match)
+}
+
+//splitDive splits rules of the tag at the dive rule into the rules of the value,
+//the rules of the map keys and the rules of the elements.
+//Returns false if the keys rule is not closed by the endkeys rule
+func splitDive(rules []string) (value, keys, elem []string, dive bool, ok bool) {
+	for i, r := range rules {
+		if r != "dive" {
+			continue
+		}
+		value, elem = rules[:i], rules[i+1:]
+		if len(elem) == 0 || elem[0] != "keys" {
+			return value, nil, elem, true, true
+		}
+		for j, r := range elem {
+			if r == "endkeys" {
+				return value, elem[1:j], elem[j+1:], true, true
+			}
+		}
+		return value, nil, nil, true, false
+	}
+	return rules, nil, nil, false, true
+}
+
+//diveSyntax returns the rule which can not be applied to the type or the empty string
+func (g *generator) diveSyntax(info typeInfo, keys []string, dive bool, ok bool) (string, error) {
+	if !ok {
+		return "keys", nil
+	}
+	if !dive {
+		return "", nil
+	}
+	switch info.kind {
+	case kindPtr, kindUnknown:
+		return "", fmt.Errorf("dive is not supported for pointer and imported types")
+	case kindMap:
+		//map keys can not be normalized
+		for _, r := range keys {
+			switch r {
+			case "trim", "lower", "upper", "collapse":
+				return r, nil
+			}
+		}
+		return "", nil
+	case kindSlice, kindArray:
+		if len(keys) == 0 {
+			return "", nil
+		}
+		return "keys", nil
+	}
+	return "dive", nil
+}
+
+//diveFields returns fields describing the map keys and the elements of the value
+//of the field with the dive rule. The field is nil if there are no rules for it
+func (g *generator) diveFields(info typeInfo, field *fieldNode) (keyField, elemField *fieldNode, err error) {
+	if field == nil {
+		return nil, nil, nil
+	}
+	sTag, ok := field.tag.Lookup("check")
+	if !ok {
+		return nil, nil, nil
+	}
+	rules, err := g.expand(splitTag(sTag), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, keys, elem, dive, ok := splitDive(rules)
+	if syntax, err := g.diveSyntax(info, keys, dive, ok); err != nil || syntax != "" || !dive {
+		return nil, nil, err
+	}
+	if len(keys) > 0 {
+		keyField = &fieldNode{
+			name:   field.name,
+			tag:    reflect.StructTag("check:" + strconv.Quote(strings.Join(keys, ","))),
+			parent: field.parent,
+		}
+	}
+	if len(elem) > 0 {
+		elemField = &fieldNode{
+			name:   field.name,
+			tag:    reflect.StructTag("check:" + strconv.Quote(strings.Join(elem, ","))),
+			parent: field.parent,
+		}
+	}
+	return keyField, elemField, nil
+}
+
+//minlen returns statement appending error of the minlen rule
+func (g *generator) minlen(info typeInfo, arg string, tagCheck string, access string, field *fieldNode) (string, error) {
+	name := strconv.Quote(field.name)
+	n, err := strconv.Atoi(arg)
+	switch info.kind {
+	case kindString, kindSlice, kindArray, kindMap:
+		if err == nil && n >= 0 {
+			return fmt.Sprintf("errs = checks.Append(errs, checks.MinLen(%s, %s, %d, len(%s)))\n",
+				name, strconv.Quote(tagCheck), n, access), nil
+		}
+	case kindPtr, kindUnknown:
+		return "", fmt.Errorf("minlen is not supported for pointer and imported types")
+	}
+	return fmt.Sprintf("errs = append(errs, checks.NewError(checks.ErrBadSyntax, %s, %s, checks.ErrorType))\n",
+		name, strconv.Quote(tagCheck)), nil
+}
+
+//groups wraps body of the rules by the check of the groups, the group with
+//the empty argument is the result unless the groups before it are active
+func (g *generator) groups(groups []string, name string, body string) []string {
+	if len(groups) == 0 {
+		if body == "" {
+			return nil
+		}
+		return []string{body}
+	}
+	var active []string
+	invalid := ""
+	for _, group := range groups {
+		if group == "" {
+			invalid = fmt.Sprintf("errs = append(errs, checks.NewError(checks.ErrBadSyntax, %s, %q, checks.ErrorType))\n",
+				name, "group:")
+			break
+		}
+		active = append(active, strings.Split(group, ";")...)
+	}
+	if len(active) == 0 {
+		return []string{invalid}
+	}
+	vars := g.addVar("values", fmt.Sprintf("%#v", active))
+	if invalid == "" {
+		return []string{fmt.Sprintf("if c.InGroup(%s) {\n%s}\n", vars, body)}
+	}
+	return []string{fmt.Sprintf("if c.InGroup(%s) {\n%s} else {\n%s}\n", vars, body, invalid)}
+}
+
+//condition returns expression of the argument of the `if` rule or the name
+//of the check error if the argument is invalid
+func (g *generator) condition(arg string, field *fieldNode) (string, string, error) {
+	if arg == "" {
+		return "", "checks.ErrBadSyntax", nil
+	}
+	op, values := "", ""
+	if idx := strings.Index(arg, "!="); idx >= 0 {
+		arg, op, values = arg[:idx], "!=", arg[idx+2:]
+	} else if idx := strings.Index(arg, "="); idx >= 0 {
+		arg, op, values = arg[:idx], "=", arg[idx+1:]
+	} else if strings.HasPrefix(arg, "!") {
+		arg, op = arg[1:], "!"
+	}
+	expr := g.fieldType(field.parent, arg)
+	if expr == nil {
+		return "", "checks.ErrFieldNotFound", nil
+	}
+	info, err := g.typeOf(expr)
+	if err != nil {
+		return "", "", err
+	}
+	access := "v." + arg
+
+	var cond string
+	switch op {
+	case "", "!":
+		switch {
+		case info.kind == kindBool:
+			cond = access
+		case info.kind == kindPtr && g.isBool(info.elem):
+			cond = fmt.Sprintf("%s != nil && *%s", access, access)
+		default:
+			cond = fmt.Sprintf("!(%s)", g.zero(info, access))
+		}
+	default:
+		vars := g.addVar("values", fmt.Sprintf("%#v", strings.Split(values, ";")))
+		switch {
+		case info.kind == kindPtr:
+			cond = fmt.Sprintf("%s != nil && checks.OneOf(*%s, %s)", access, access, vars)
+		case info.nilable():
+			cond = fmt.Sprintf("%s != nil && checks.OneOf(%s, %s)", access, access, vars)
+		default:
+			cond = fmt.Sprintf("checks.OneOf(%s, %s)", access, vars)
+		}
+	}
+	if op == "!" || op == "!=" {
+		cond = fmt.Sprintf("!(%s)", cond)
+	}
+	return cond, "", nil
+}
+
+//isString reports whether values of the type are normalized by rules
+func (g *generator) isString(info typeInfo) bool {
+	if info.kind == kindPtr {
+		elem, err := g.typeOf(info.elem)
+		return err == nil && elem.kind == kindString
+	}
+	return info.kind == kindString
+}
+
+func (g *generator) isBool(expr ast.Expr) bool {
+	info, err := g.typeOf(expr)
+	return err == nil && info.kind == kindBool
+}
+
+//fieldType returns type of the field of the struct declared in the package
+func (g *generator) fieldType(structName string, fieldName string) ast.Expr {
+	st, ok := g.types[structName].Type.(*ast.StructType)
+	if !ok {
+		return nil
+	}
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 && embeddedName(field.Type) == fieldName {
+			return field.Type
+		}
+		for _, ident := range field.Names {
+			if ident.Name == fieldName {
+				return field.Type
+			}
+		}
+	}
+	return nil
+}
+
+//call returns statements calling the method of the call check. Methods of the parent
+//are looked up first, then methods of the type of the field declared in the package.
+//Methods of other types and registered functions are looked up at run time
+func (g *generator) call(arg string, tagCheck string, expr ast.Expr, access string, field *fieldNode) (string, error) {
+	name, quotedRule := strconv.Quote(field.name), strconv.Quote(tagCheck)
+	method, args, ok := parseCall(arg)
+	if !ok {
+		return fmt.Sprintf("errs = append(errs, checks.NewError(checks.ErrBadSyntax, %s, %s, checks.ErrorType))\n",
+			name, quotedRule), nil
+	}
+	recv, owner := "v", true
+	fn, ok := g.methods[field.parent][method]
+	if !ok {
+		typeName, pointer := localType(expr)
+		fn, ok = g.methods[typeName][method]
+		if !ok || (g.pointer[typeName][method] && !pointer) {
+			return fmt.Sprintf("errs = checks.Append(errs, c.Call(%s, %s, &%s)...)\n", name, quotedRule, access), nil
+		}
+		recv, owner = access, false
+	}
+	params, invalid, err := g.callArgs(fn, owner, name, access, args)
+	if err != nil {
+		return "", fmt.Errorf("call:%s: %s", method, err)
+	}
+	if invalid == "" {
+		invalid = "checks.ErrWrongSignatureMethod"
+		if fn.Results.NumFields() == 1 {
+			switch result := fn.Results.List[0].Type.(type) {
+			case *ast.Ident:
+				if result.Name == "error" {
+					return fmt.Sprintf("errs = checks.Append(errs, %s.%s(%s))\n", recv, method, params), nil
+				}
+			case *ast.ArrayType:
+				if isIdent(result.Elt, "error") && result.Len == nil {
+					return fmt.Sprintf("errs = checks.Append(errs, %s.%s(%s)...)\n", recv, method, params), nil
+				}
+			}
+		}
+	}
+	return fmt.Sprintf("errs = append(errs, checks.NewError(%s, %s, %s, checks.ErrorType))\n",
+		invalid, name, quotedRule), nil
+}
+
+//callArgs returns arguments of the method of the call check:
+//[ctx context.Context] [name string | field checks.Field] [value T] [args...].
+//Returns the error of the check if the method does not accept the arguments
+func (g *generator) callArgs(fn *ast.FuncType, owner bool, name string, access string, args []string) (string, string, error) {
+	const wrong = "checks.ErrWrongSignatureMethod"
+	var params []ast.Expr
+	for _, param := range fn.Params.List {
+		if _, ok := param.Type.(*ast.Ellipsis); ok {
+			return "", wrong, nil
+		}
+		for i := 0; i < len(param.Names) || i == 0; i++ {
+			params = append(params, param.Type)
+		}
+	}
+	if fn.Results.NumFields() != 1 {
+		return "", wrong, nil
+	}
+	var result []string
+	if len(params) > 0 && isSelector(params[0], "context", "Context") {
+		result = append(result, "c.Context()")
+		params = params[1:]
+	}
+	if len(params) < len(args) {
+		return "", wrong, nil
+	}
+	values := make([]string, 0, len(args)+1)
+	if owner {
+		if len(params) == len(args) {
+			return "", wrong, nil
+		}
+		values = append(values, access)
+	}
+	invalid := ""
+	for i, arg := range args {
+		kind, err := g.basicType(params[len(params)-len(args)+i])
+		if err != nil || kind == "" {
+			return "", wrong, err
+		}
+		value, ok := literal(kind, arg)
+		if !ok {
+			invalid = "checks.ErrBadSyntax"
+		}
+		values = append(values, value)
+	}
+	params = params[:len(params)-len(args)]
+	if owner {
+		params = params[:len(params)-1]
+	}
+	switch {
+	case len(params) == 0:
+	case len(params) > 1:
+		return "", wrong, nil
+	case isSelector(params[0], "checks", "Field"):
+		result = append(result, fmt.Sprintf("checks.Field{Name: %s, Path: c.Path()}", name))
+	case isIdent(params[0], "string"), isEmptyInterface(params[0]):
+		result = append(result, name)
+	default:
+		return "", wrong, nil
+	}
+	return strings.Join(append(result, values...), ", "), invalid, nil
+}
+
+//basicType returns the name of the basic type of the parameter accepting arguments of the call check
+//or empty string if the type can not accept them
+func (g *generator) basicType(expr ast.Expr) (string, error) {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if spec, ok := g.types[expr.Name]; ok {
+			return g.basicType(spec.Type)
+		}
+		switch expr.Name {
+		case "string", "bool", "float32", "float64",
+			"int", "int8", "int16", "int32", "int64", "rune",
+			"uint", "uint8", "uint16", "uint32", "uint64", "byte":
+			return expr.Name, nil
+		}
+	case *ast.ParenExpr:
+		return g.basicType(expr.X)
+	case *ast.SelectorExpr:
+		return "", errors.New("arguments of imported types are not supported")
+	}
+	return "", nil
+}
+
+//literal returns the constant of the argument of the call check for the basic type
+func literal(kind string, arg string) (string, bool) {
+	var err error
+	switch kind {
+	case "string":
+		return strconv.Quote(arg), true
+	case "bool":
+		var b bool
+		b, err = strconv.ParseBool(arg)
+		arg = strconv.FormatBool(b)
+	case "float32", "float64":
+		var f float64
+		bits := 64
+		if kind == "float32" {
+			bits = 32
+		}
+		f, err = strconv.ParseFloat(arg, bits)
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return "", false
+		}
+		arg = strconv.FormatFloat(f, 'g', -1, bits)
+	case "uint", "uint8", "uint16", "uint32", "uint64", "byte":
+		var n uint64
+		n, err = strconv.ParseUint(arg, 10, bitSize(kind))
+		arg = strconv.FormatUint(n, 10)
+	default:
+		var n int64
+		n, err = strconv.ParseInt(arg, 10, bitSize(kind))
+		arg = strconv.FormatInt(n, 10)
+	}
+	return arg, err == nil
+}
+
+func bitSize(kind string) int {
+	switch kind {
+	case "int8", "uint8", "byte":
+		return 8
+	case "int16", "uint16":
+		return 16
+	case "int32", "uint32", "rune":
+		return 32
+	case "int64", "uint64":
+		return 64
+	}
+	return strconv.IntSize
+}
+
+//parseCall parses the argument of the call rule: Name or Name(arg1,arg2)
+func parseCall(arg string) (string, []string, bool) {
+	idx := strings.Index(arg, "(")
+	if idx < 0 {
+		return arg, nil, arg != ""
+	}
+	if idx == 0 || !strings.HasSuffix(arg, ")") {
+		return "", nil, false
+	}
+	var args []string
+	if inner := strings.TrimSpace(arg[idx+1 : len(arg)-1]); inner != "" {
+		for _, a := range strings.Split(inner, ",") {
+			args = append(args, strings.TrimSpace(a))
+		}
+	}
+	return arg[:idx], args, true
+}
+
+//splitTag splits the tag into rules. Commas inside the parentheses of the call rule
+//separate arguments of the method, not rules
+func splitTag(sTag string) []string {
+	var (
+		result []string
+		depth  int
+		start  int
+	)
+	for i := 0; i < len(sTag); i++ {
+		switch sTag[i] {
+		case '(':
+			if strings.HasPrefix(sTag[start:], "call:") {
+				depth++
+			}
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				result = append(result, sTag[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(result, sTag[start:])
+}
+
+//localType returns the name of the type declared in the package and
+//whether the type is a pointer to it
+func localType(expr ast.Expr) (string, bool) {
+	star, pointer := expr.(*ast.StarExpr)
+	if pointer {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name, pointer
+	}
+	return "", false
+}
+
+func isSelector(expr ast.Expr, pkg string, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	return ok && isIdent(sel.X, pkg) && sel.Sel.Name == name
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+func isEmptyInterface(expr ast.Expr) bool {
+	iface, ok := expr.(*ast.InterfaceType)
+	return ok && iface.Methods.NumFields() == 0
+}
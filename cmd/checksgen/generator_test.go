@@ -0,0 +1,65 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	dir := filepath.Join("..", "..", "internal", "gentest")
+	want, err := ioutil.ReadFile(filepath.Join(dir, "checks_gen.go"))
+	assert.NoError(t, err)
+
+	got, err := generate(dir, []string{"Config", "Nested"}, "checks_gen.go")
+	assert.NoError(t, err)
+	assert.Equal(t, string(want), string(got), "checks_gen.go is out of date, run go generate")
+}
+
+func TestGenerateErrors(t *testing.T) {
+	dir := filepath.Join("..", "..", "internal", "gentest")
+	_, err := generate(dir, []string{"Unknown"}, "checks_gen.go")
+	assert.EqualError(t, err, "type not found: Unknown")
+
+	_, err = generate(dir, []string{"Level"}, "checks_gen.go")
+	assert.EqualError(t, err, "type is not a struct: Level")
+
+	_, err = generate(t.Name(), []string{"Config"}, "checks_gen.go")
+	assert.EqualError(t, err, "no go files in "+t.Name())
+}
+
+func TestGenerateUnsupported(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checksgen")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	src := "package p\n\ntype T struct {\n\tTags *[]string `check:\"dive,required\"`\n\tName *string `check:\"minlen:1\"`\n}\n"
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0644))
+	_, err = generate(dir, []string{"T"}, "checks_gen.go")
+	assert.EqualError(t, err, "T.Tags: dive is not supported for pointer and imported types")
+
+	src = "package p\n\ntype T struct {\n\tName *string `check:\"minlen:1\"`\n}\n"
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0644))
+	_, err = generate(dir, []string{"T"}, "checks_gen.go")
+	assert.EqualError(t, err, "T.Name: minlen is not supported for pointer and imported types")
+
+	src = "package p\n\nimport \"time\"\n\ntype T struct {\n\tM map[time.Duration]string `check:\"dive,required\"`\n}\n"
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0644))
+	_, err = generate(dir, []string{"T"}, "checks_gen.go")
+	assert.EqualError(t, err, "T.M: map keys of imported types are not supported")
+
+	src = "package p\n\nimport \"time\"\n\ntype T struct {\n\tV int `check:\"call:Valid(1s)\"`\n}\n\n" +
+		"func (T) Valid(v int, d time.Duration) error { return nil }\n"
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0644))
+	_, err = generate(dir, []string{"T"}, "checks_gen.go")
+	assert.EqualError(t, err, "T.V: call:Valid: arguments of imported types are not supported")
+
+	src = "package p\n\nimport \"github.com/arteev/go-checks\"\n\nfunc init() {\n\tchecks.RegisterAlias(\"port\", \"required\")\n}\n\n" +
+		"type T struct {\n\tPort int `check:\"port\"`\n\tName string `check:\"name\"`\n}\n"
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0644))
+	_, err = generate(dir, []string{"T"}, "checks_gen.go")
+	assert.EqualError(t, err, "T.Name: unknown check: name")
+}
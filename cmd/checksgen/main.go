@@ -0,0 +1,60 @@
+//Command checksgen generates reflection-free check functions for structs
+//with `check` tags.
+//
+//Usage:
+//
+//	//go:generate checksgen -type Config
+//
+//Generated functions are registered with checks.Register and are used by
+//checkers created with the checks.WithGenerated option.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of type names; must be set")
+	output    = flag.String("output", "", "output file name; default <dir>/checks_gen.go")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of checksgen:\n")
+	fmt.Fprintf(os.Stderr, "\tchecksgen [flags] -type T [directory]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("checksgen: ")
+	flag.Usage = usage
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+	outputName := *output
+	if outputName == "" {
+		outputName = filepath.Join(dir, "checks_gen.go")
+	}
+
+	src, err := generate(dir, strings.Split(*typeNames, ","), filepath.Base(outputName))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(outputName, src, 0644); err != nil {
+		log.Fatalf("writing output: %s", err)
+	}
+}
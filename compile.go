@@ -0,0 +1,222 @@
+package checks
+
+import (
+	"reflect"
+	"strings"
+)
+
+type (
+	//TagError describes the invalid check of the `check` tag
+	TagError struct {
+		Path string
+		Rule string
+		Err  error
+	}
+
+	//CompileError contains all errors of the `check` tags of the type
+	CompileError struct {
+		Type   reflect.Type
+		Errors []*TagError
+	}
+
+	compiler struct {
+		visited map[reflect.Type]bool
+		errors  []*TagError
+	}
+)
+
+var errorInterface = reflect.TypeOf((*error)(nil)).Elem()
+
+func (e *TagError) Error() string {
+	return e.Err.Error() + ": " + e.Path + " " + e.Rule
+}
+
+func (e *CompileError) Error() string {
+	lines := make([]string, 0, len(e.Errors)+1)
+	lines = append(lines, "invalid check tags of "+e.Type.String()+":")
+	for _, err := range e.Errors {
+		lines = append(lines, "\t"+err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+//Compile checks `check` tags of the type and of the nested types.
+//Returns *CompileError with all errors found
+func Compile(t reflect.Type) error {
+	c := &compiler{
+		visited: make(map[reflect.Type]bool),
+	}
+	c.compileType(t, "")
+	if len(c.errors) == 0 {
+		return nil
+	}
+	return &CompileError{
+		Type:   t,
+		Errors: c.errors,
+	}
+}
+
+//MustCompile is like Compile but panics if the tags are invalid
+func MustCompile(t reflect.Type) {
+	if err := Compile(t); err != nil {
+		panic(err)
+	}
+}
+
+func (c *compiler) addError(path string, r rule, err error) {
+	c.errors = append(c.errors, &TagError{
+		Path: path,
+		Rule: r.text,
+		Err:  err,
+	})
+}
+
+func (c *compiler) compileType(t reflect.Type, path string) {
+	switch t.Kind() {
+	case reflect.Map:
+		//keys of maps are walked under dive,keys...endkeys
+		c.compileType(t.Key(), path)
+		c.compileType(t.Elem(), path)
+		return
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		c.compileType(t.Elem(), path)
+		return
+	case reflect.Struct:
+	default:
+		return
+	}
+	if c.visited[t] {
+		return
+	}
+	c.visited[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		if sTag, ok := field.Tag.Lookup("check"); ok {
+			c.compileRules(t, field, parseTag(sTag), fieldPath)
+		}
+		c.compileType(field.Type, fieldPath)
+	}
+}
+
+//compileRules checks rules of the field and rules of its map keys and elements
+func (c *compiler) compileRules(parent reflect.Type, field reflect.StructField, rules []rule, path string) {
+	rules, keys, elem, dive, ok := splitDive(rules)
+	for _, r := range rules {
+		if err := compileRule(parent, field, r); err != nil {
+			c.addError(path, r, err)
+		}
+	}
+	if syntax := diveSyntax(field.Type, keys, dive, ok); syntax != "" {
+		c.addError(path, rule{name: syntax, text: syntax}, ErrBadSyntax)
+		return
+	}
+	if !dive {
+		return
+	}
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if len(keys) > 0 {
+		c.compileRules(parent, reflect.StructField{Name: field.Name, Type: t.Key()}, keys, path)
+	}
+	if len(elem) > 0 {
+		c.compileRules(parent, reflect.StructField{Name: field.Name, Type: t.Elem()}, elem, path)
+	}
+}
+
+func compileRule(parent reflect.Type, field reflect.StructField, r rule) error {
+	if !r.known() {
+		return ErrUnknownCheck
+	}
+	if r.hasArg() && r.arg == "" {
+		return ErrBadSyntax
+	}
+	switch r.name {
+	case ruleKeys, ruleEndKeys:
+		return ErrBadSyntax
+	case ruleMinLen:
+		if _, ok := minLength(r.arg); !ok || !lengthType(field.Type) {
+			return ErrBadSyntax
+		}
+	case ruleTrim, ruleLower, ruleUpper, ruleCollapse:
+		if !stringType(field.Type) {
+			return ErrBadSyntax
+		}
+	case ruleUnique:
+		return uniqueSyntax(field.Type, r)
+	case ruleRegexp, ruleNotRegexp, ruleMatch, ruleNotMatch:
+		if _, err := r.regexp(); err != nil {
+			return err
+		}
+	case ruleIf:
+		if _, ok := parent.FieldByName(parseCondition(r.arg).field); !ok {
+			return ErrFieldNotFound
+		}
+	case ruleCall:
+		return compileCall(parent, field, r.arg)
+	case ruleExpect, ruleIExpect:
+		if strings.HasPrefix(r.arg, "@") {
+			return compileValues(parent, r.arg[1:])
+		}
+	}
+	return nil
+}
+
+//compileValues checks the method of the parent with the value receiver or the registered
+//function returning values of expect:@Name
+func compileValues(parent reflect.Type, name string) error {
+	if name == "" {
+		return ErrBadSyntax
+	}
+	if method, ok := parent.MethodByName(name); ok {
+		t := method.Type
+		if t.NumIn() != 1 || t.NumOut() != 1 || t.Out(0).Kind() != reflect.Slice {
+			return ErrWrongSignatureMethod
+		}
+		return nil
+	}
+	if _, ok := lookupValues(name); ok {
+		return nil
+	}
+	return ErrMethodNotFound
+}
+
+//compileCall checks the method of the call check. Methods of the parent are looked up
+//first, then methods of the type of the field and registered functions
+func compileCall(parent reflect.Type, field reflect.StructField, arg string) error {
+	c, ok := parseCall(arg)
+	if !ok {
+		return ErrBadSyntax
+	}
+	if method, ok := reflect.PtrTo(parent).MethodByName(c.name); ok {
+		return compileSignature(method.Type, 1, field.Type, true, c)
+	}
+	if field.Type.Kind() == reflect.Interface {
+		//methods of the value held by the interface are not known
+		return nil
+	}
+	if method, ok := field.Type.MethodByName(c.name); ok {
+		return compileSignature(method.Type, 1, field.Type, false, c)
+	}
+	if fn, ok := lookupFunc(c.name); ok {
+		return compileSignature(fn.Type(), 0, field.Type, true, c)
+	}
+	return ErrMethodNotFound
+}
+
+func compileSignature(method reflect.Type, skip int, value reflect.Type, owner bool, c callRule) error {
+	sig, ok := parseSignature(method, skip, value, owner, len(c.args))
+	if !ok {
+		return ErrWrongSignatureMethod
+	}
+	if _, ok := sig.values(c.args); !ok {
+		return ErrBadSyntax
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package checks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCompileNested struct {
+	Name  string `check:"required,expect:a;b"`
+	Typo  string `check:"requierd"`
+	Value int    `check:"call:CheckValue"`
+}
+
+type testCompile struct {
+	Listen   string `check:"required"`
+	BadRe    string `check:"re:[a-z"`
+	Empty    string `check:"expect:"`
+	Missing  string `check:"call:Missing"`
+	Wrong    string `check:"call:CheckValue"`
+	Required string `check:"required:yes"`
+
+	Nested  testCompileNested
+	Slice   []*testCompileNested
+	Map     map[string]testCompileNested
+	Self    *testCompile
+	private string `check:"unknown"`
+}
+
+func (testCompileNested) CheckValue(name string, value int) error { return nil }
+
+func (*testCompile) CheckValue(name string, value int) error { return nil }
+
+func TestCompile(t *testing.T) {
+	assert.NoError(t, Compile(reflect.TypeOf(0)))
+	assert.NoError(t, Compile(reflect.TypeOf(testTags{})))
+	assert.NoError(t, Compile(reflect.TypeOf([]TestMethod{})))
+
+	err := Compile(reflect.TypeOf(&testCompile{}))
+	if !assert.IsType(t, &CompileError{}, err) {
+		return
+	}
+	errs := err.(*CompileError).Errors
+	got := make([]string, 0, len(errs))
+	for _, e := range errs {
+		got = append(got, e.Error())
+	}
+	assert.Equal(t, []string{
+		"error parsing regexp: missing closing ]: `[a-z`: BadRe re:[a-z",
+		"bad syntax: Empty expect:",
+		"method not found: Missing call:Missing",
+		"wrong signature method: Wrong call:CheckValue",
+		"unknown check: Required required:yes",
+		"unknown check: Nested.Typo requierd",
+		"unknown check: private unknown",
+	}, got)
+	assert.Equal(t, ErrUnknownCheck, errs[4].Err)
+	assert.Equal(t, "Nested.Typo", errs[5].Path)
+	assert.Equal(t, "requierd", errs[5].Rule)
+
+	assert.Equal(t, "invalid check tags of *checks.testCompileNested:\n"+
+		"\tunknown check: Typo requierd",
+		Compile(reflect.TypeOf(&testCompileNested{})).Error())
+}
+
+type testCompileValues struct {
+	Driver   string   `check:"expect:@AllowedDrivers"`
+	Drivers  []string `check:"dive,iexpect:@AllowedDrivers"`
+	Plugin   string   `check:"expect:@TestCompilePlugins"`
+	Missing  string   `check:"expect:@Missing"`
+	Wrong    string   `check:"iexpect:@WrongValues"`
+	Pointer  string   `check:"expect:@PointerValues"`
+	Bad      string   `check:"expect:@"`
+	Literals string   `check:"expect:a;b"`
+}
+
+func (testCompileValues) AllowedDrivers() []string { return nil }
+
+func (testCompileValues) WrongValues(name string) []string { return nil }
+
+func (*testCompileValues) PointerValues() []string { return nil }
+
+func TestCompileValues(t *testing.T) {
+	RegisterValues("TestCompilePlugins", func() []string {
+		return nil
+	})
+	err := Compile(reflect.TypeOf(testCompileValues{}))
+	assert.EqualError(t, err, "invalid check tags of checks.testCompileValues:\n"+
+		"\tmethod not found: Missing expect:@Missing\n"+
+		"\twrong signature method: Wrong iexpect:@WrongValues\n"+
+		"\tmethod not found: Pointer expect:@PointerValues\n"+
+		"\tbad syntax: Bad expect:@")
+
+	//run-time checks report the same errors
+	errs := New(ModeAll, ErrorAll).Check(&testCompileValues{Driver: "x", Plugin: "x", Missing: "x", Wrong: "x", Pointer: "x", Literals: "a"})
+	assert.Len(t, errs, 6)
+	assert.EqualError(t, errs[2], "method not found: Missing expect:@Missing")
+	assert.EqualError(t, errs[3], "wrong signature method: Wrong iexpect:@WrongValues")
+	assert.EqualError(t, errs[4], "method not found: Pointer expect:@PointerValues")
+	assert.EqualError(t, errs[5], "bad syntax: Bad expect:@")
+}
+
+func TestCompileMapKeys(t *testing.T) {
+	type testKey struct {
+		Name string `check:"bogus"`
+	}
+	type testKeys struct {
+		Hosts map[testKey]string `check:"dive,keys,required,endkeys"`
+	}
+	assert.EqualError(t, Compile(reflect.TypeOf(testKeys{})), "invalid check tags of checks.testKeys:\n"+
+		"\tunknown check: Hosts.Name bogus")
+
+	errs := New(ModeAll, ErrorAll).Check(&testKeys{Hosts: map[testKey]string{{Name: "a"}: "b"}})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "unknown check: bogus")
+}
+
+func TestMustCompile(t *testing.T) {
+	assert.NotPanics(t, func() {
+		MustCompile(reflect.TypeOf(testTags{}))
+	})
+	assert.Panics(t, func() {
+		MustCompile(reflect.TypeOf(testCompileNested{}))
+	})
+}
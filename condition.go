@@ -0,0 +1,122 @@
+package checks
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+//Operators of the conditions
+const (
+	condNotZero = iota
+	condZero
+	condEqual
+	condNotEqual
+)
+
+//condition is the parsed argument of the `if` rule:
+//Field, !Field, Field=value1;value2 or Field!=value1;value2
+type condition struct {
+	field  string
+	op     int
+	values []string
+}
+
+func parseCondition(arg string) condition {
+	if idx := strings.Index(arg, "!="); idx >= 0 {
+		return condition{field: arg[:idx], op: condNotEqual, values: strings.Split(arg[idx+2:], ";")}
+	}
+	if idx := strings.Index(arg, "="); idx >= 0 {
+		return condition{field: arg[:idx], op: condEqual, values: strings.Split(arg[idx+1:], ";")}
+	}
+	if strings.HasPrefix(arg, "!") {
+		return condition{field: arg[1:], op: condZero}
+	}
+	return condition{field: arg, op: condNotZero}
+}
+
+//holds reports whether the condition holds for the value of the field
+func (c condition) holds(value reflect.Value) bool {
+	switch c.op {
+	case condNotZero:
+		return truthy(value)
+	case condZero:
+		return !truthy(value)
+	}
+	value = reflect.Indirect(value)
+	equal := !isNil(value) && value.IsValid() && value.CanInterface() &&
+		hasValue(fmt.Sprintf("%v", value.Interface()), c.values)
+	return equal == (c.op == condEqual)
+}
+
+//truthy reports whether the value is true for bool values and is not zero for others
+func truthy(value reflect.Value) bool {
+	if isNil(value) || !value.IsValid() {
+		return false
+	}
+	if v := reflect.Indirect(value); v.Kind() == reflect.Bool {
+		return v.Bool()
+	}
+	return !isZero(value)
+}
+
+//conditions reports whether all `if` rules hold for the sibling fields of the parent
+func conditions(parent reflect.Value, strField *reflect.StructField, rules []rule) (bool, error) {
+	parent = unwrap(parent)
+	for _, r := range rules {
+		if r.name != ruleIf {
+			continue
+		}
+		if r.arg == "" {
+			return false, newError(ErrBadSyntax, strField.Name, r.text, ErrorType)
+		}
+		cond := parseCondition(r.arg)
+		if parent.Kind() != reflect.Struct {
+			return false, newError(ErrFieldNotFound, strField.Name, r.text, ErrorType)
+		}
+		field, ok := conditionField(parent, cond.field)
+		if !ok {
+			return false, newError(ErrFieldNotFound, strField.Name, r.text, ErrorType)
+		}
+		if !field.IsValid() {
+			//the field is not reachable, the condition does not hold
+			return false, nil
+		}
+		if !field.CanInterface() {
+			return false, newError(ErrUnexported, strField.Name, r.text, ErrorType)
+		}
+		if !cond.holds(field) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+//conditionField returns the sibling field of the condition. Unexported fields are
+//read from the addressable copy of the parent like generated code reads them.
+//The field promoted through the nil embedded pointer is invalid
+func conditionField(parent reflect.Value, name string) (reflect.Value, bool) {
+	sf, ok := parent.Type().FieldByName(name)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	readable := parent.CanAddr() || parent.CanInterface()
+	v := parent
+	if readable {
+		v = addressable(parent)
+	}
+	for i, index := range sf.Index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, true
+			}
+			v = v.Elem()
+		}
+		if readable {
+			v = accessible(v, index)
+		} else {
+			v = v.Field(index)
+		}
+	}
+	return v, true
+}
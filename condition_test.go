@@ -0,0 +1,134 @@
+package checks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCondition(t *testing.T) {
+	assert.Equal(t, condition{field: "Enabled", op: condNotZero}, parseCondition("Enabled"))
+	assert.Equal(t, condition{field: "Enabled", op: condZero}, parseCondition("!Enabled"))
+	assert.Equal(t, condition{field: "Mode", op: condEqual, values: []string{"tls", "mtls"}},
+		parseCondition("Mode=tls;mtls"))
+	assert.Equal(t, condition{field: "Mode", op: condNotEqual, values: []string{"none"}},
+		parseCondition("Mode!=none"))
+}
+
+func TestConditionHolds(t *testing.T) {
+	s := "tls"
+	tests := []struct {
+		arg   string
+		value interface{}
+		must  bool
+	}{
+		{"Enabled", true, true},
+		{"Enabled", false, false},
+		{"!Enabled", false, true},
+		{"!Enabled", true, false},
+		{"Name", "", false},
+		{"Name", "name", true},
+		{"Ptr", (*string)(nil), false},
+		{"!Ptr", (*string)(nil), true},
+		{"Mode=tls;mtls", "mtls", true},
+		{"Mode=tls", "none", false},
+		{"Mode=tls", &s, true},
+		{"Mode=tls", (*string)(nil), false},
+		{"Mode!=tls", (*string)(nil), true},
+		{"Mode!=tls", "tls", false},
+		{"Port=80", 80, true},
+	}
+	for _, test := range tests {
+		got := parseCondition(test.arg).holds(reflect.ValueOf(test.value))
+		assert.Equal(t, test.must, got, "%s: %v", test.arg, test.value)
+	}
+}
+
+func TestCheckConditions(t *testing.T) {
+	type testTLS struct {
+		Enabled bool
+		Mode    string
+		Listen  string `check:"required,if:Enabled"`
+		Cert    string `check:"required,if:Enabled,if:Mode=tls;mtls"`
+		CA      string `check:"if:Mode=mtls,required"`
+		Plain   string `check:"if:Mode!=tls;mtls,required"`
+	}
+	errs := CheckAll(testTLS{})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "value required: Plain")
+
+	errs = CheckAll(testTLS{Enabled: true, Mode: "tls"})
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "value required: Listen")
+	assert.EqualError(t, errs[1], "value required: Cert")
+
+	errs = CheckAll(&testTLS{Enabled: true, Mode: "mtls", Listen: ":443", Cert: "cert"})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "value required: CA")
+
+	errs = CheckAll(testTLS{Mode: "mtls"})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "value required: CA")
+
+	type testInvalid struct {
+		Empty   string `check:"required,if:"`
+		Unknown string `check:"required,if:Missing"`
+	}
+	errs = CheckAll(testInvalid{})
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "bad syntax: Empty if:")
+	assert.EqualError(t, errs[1], "field not found: Unknown if:Missing")
+
+	err := Compile(reflect.TypeOf(testInvalid{}))
+	assert.EqualError(t, err, "invalid check tags of checks.testInvalid:\n"+
+		"\tbad syntax: Empty if:\n"+
+		"\tfield not found: Unknown if:Missing")
+	assert.NoError(t, Compile(reflect.TypeOf(testTLS{})))
+}
+
+type testConditionBase struct {
+	secure bool
+}
+
+func TestCheckConditionsUnexported(t *testing.T) {
+	type testUnexported struct {
+		testConditionBase
+		enabled bool
+		mode    string
+		name    string
+		User    string `check:"required,if:name"`
+		Listen  string `check:"required,if:enabled"`
+		Cert    string `check:"required,if:mode=tls"`
+		Key     string `check:"required,if:secure"`
+	}
+	assert.NoError(t, Compile(reflect.TypeOf(testUnexported{})))
+	v := testUnexported{enabled: true, mode: "tls", name: "admin", testConditionBase: testConditionBase{secure: true}}
+	for _, value := range []interface{}{v, &v} {
+		errs := CheckAll(value)
+		assert.Len(t, errs, 4)
+		assert.EqualError(t, errs[0], "value required: User")
+		assert.EqualError(t, errs[1], "value required: Listen")
+		assert.EqualError(t, errs[2], "value required: Cert")
+		assert.EqualError(t, errs[3], "value required: Key")
+	}
+	assert.NoError(t, Check(testUnexported{}))
+}
+
+type testConditionEmbedded struct {
+	Enabled bool
+}
+
+func TestCheckConditionsNilEmbedded(t *testing.T) {
+	type testNilEmbedded struct {
+		*testConditionEmbedded
+		Listen string `check:"required,if:Enabled"`
+	}
+	//fields promoted through nil pointers are not reachable and conditions do not hold
+	assert.NoError(t, Check(&testNilEmbedded{}))
+	assert.NoError(t, Check(testNilEmbedded{}))
+
+	errs := CheckAll(&testNilEmbedded{testConditionEmbedded: &testConditionEmbedded{Enabled: true}})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "value required: Listen")
+}
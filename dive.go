@@ -0,0 +1,124 @@
+package checks
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+//splitDive splits rules of the tag at the dive rule into the rules of the value,
+//the rules of the map keys and the rules of the elements.
+//Returns false if the keys rule is not closed by the endkeys rule
+func splitDive(rules []rule) (value, keys, elem []rule, dive bool, ok bool) {
+	for i, r := range rules {
+		if r.text != ruleDive {
+			continue
+		}
+		value, elem = rules[:i], rules[i+1:]
+		if len(elem) == 0 || elem[0].text != ruleKeys {
+			return value, nil, elem, true, true
+		}
+		for j, r := range elem {
+			if r.text == ruleEndKeys {
+				return value, elem[1:j], elem[j+1:], true, true
+			}
+		}
+		return value, nil, nil, true, false
+	}
+	return rules, nil, nil, false, true
+}
+
+//joinRules returns the `check` tag of the rules
+func joinRules(rules []rule) reflect.StructTag {
+	texts := make([]string, 0, len(rules))
+	for _, r := range rules {
+		texts = append(texts, r.text)
+	}
+	return reflect.StructTag("check:" + strconv.Quote(strings.Join(texts, ",")))
+}
+
+//diveSyntax returns the rule which can not be applied to the type of the field
+//or the empty string. Map keys can not be normalized
+func diveSyntax(t reflect.Type, keys []rule, dive bool, ok bool) string {
+	if !ok {
+		return ruleKeys
+	}
+	if !dive {
+		return ""
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Map:
+		for _, r := range keys {
+			if r.normalizes() {
+				return r.text
+			}
+		}
+		return ""
+	case reflect.Slice, reflect.Array:
+		if len(keys) == 0 {
+			return ""
+		}
+		return ruleKeys
+	}
+	return ruleDive
+}
+
+//diveFields returns fields describing the map keys and the elements of the value
+//of the field with the dive rule. The field is nil if there are no rules for it
+func diveFields(sf *reflect.StructField) (keyField, elemField *reflect.StructField) {
+	if sf == nil {
+		return nil, nil
+	}
+	sTag, ok := sf.Tag.Lookup("check")
+	if !ok {
+		return nil, nil
+	}
+	_, keys, elem, dive, ok := splitDive(parseTag(sTag))
+	if !dive || !ok || diveSyntax(sf.Type, keys, dive, ok) != "" {
+		return nil, nil
+	}
+	t := sf.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if len(keys) > 0 {
+		keyField = &reflect.StructField{Name: sf.Name, Type: t.Key(), Tag: joinRules(keys)}
+	}
+	if len(elem) > 0 {
+		elemField = &reflect.StructField{Name: sf.Name, Type: t.Elem(), Tag: joinRules(elem)}
+	}
+	return keyField, elemField
+}
+
+//lengthType reports whether values of the type have the length
+func lengthType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+//minLength parses the argument of the minlen rule
+func minLength(arg string) (int, bool) {
+	n, err := strconv.Atoi(arg)
+	return n, err == nil && n >= 0
+}
+
+func minlen(value reflect.Value, strField *reflect.StructField, r rule) error {
+	n, ok := minLength(r.arg)
+	if !ok || !lengthType(strField.Type) {
+		return newError(ErrBadSyntax, strField.Name, r.text, ErrorType)
+	}
+	length := 0
+	if value = reflect.Indirect(value); value.IsValid() {
+		length = value.Len()
+	}
+	return MinLen(strField.Name, r.text, n, length)
+}
@@ -0,0 +1,124 @@
+package checks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitDive(t *testing.T) {
+	value, keys, elem, dive, ok := splitDive(parseTag("required,minlen:1,dive,keys,re:^[a-z]+$,endkeys,required"))
+	assert.True(t, dive)
+	assert.True(t, ok)
+	assert.Equal(t, []rule{{name: "required", text: "required"}, {name: "minlen", arg: "1", text: "minlen:1"}}, value)
+	assert.Equal(t, []rule{{name: "re", arg: "^[a-z]+$", text: "re:^[a-z]+$"}}, keys)
+	assert.Equal(t, []rule{{name: "required", text: "required"}}, elem)
+
+	value, keys, elem, dive, ok = splitDive(parseTag("required"))
+	assert.False(t, dive)
+	assert.True(t, ok)
+	assert.Len(t, value, 1)
+	assert.Nil(t, keys)
+	assert.Nil(t, elem)
+
+	_, _, elem, dive, ok = splitDive(parseTag("dive,dive,required"))
+	assert.True(t, dive)
+	assert.True(t, ok)
+	assert.Equal(t, reflect.StructTag(`check:"dive,required"`), joinRules(elem))
+
+	_, _, _, dive, ok = splitDive(parseTag("dive,keys,required"))
+	assert.True(t, dive)
+	assert.False(t, ok)
+}
+
+func TestDiveSyntax(t *testing.T) {
+	keys := []rule{{name: "required", text: "required"}}
+	assert.Equal(t, "", diveSyntax(reflect.TypeOf([]string{}), nil, false, true))
+	assert.Equal(t, "", diveSyntax(reflect.TypeOf([]string{}), nil, true, true))
+	assert.Equal(t, "", diveSyntax(reflect.TypeOf(&[]string{}), nil, true, true))
+	assert.Equal(t, "", diveSyntax(reflect.TypeOf(map[string]int{}), keys, true, true))
+	assert.Equal(t, "keys", diveSyntax(reflect.TypeOf([1]string{}), keys, true, true))
+	assert.Equal(t, "keys", diveSyntax(reflect.TypeOf(map[string]int{}), nil, true, false))
+	assert.Equal(t, "dive", diveSyntax(reflect.TypeOf(""), nil, true, true))
+}
+
+func TestCheckDive(t *testing.T) {
+	type testDive struct {
+		Strict bool
+		Tags   []string            `check:"required,minlen:2,dive,re:^[a-z]+$"`
+		Hosts  map[string]string   `check:"dive,keys,re:^[a-z]+$,endkeys,required"`
+		Matrix [][]int             `check:"dive,minlen:1,dive,expect:0;1"`
+		Ptr    *[]string           `check:"minlen:1,dive,required,if:Strict"`
+		Plain  []string            `check:"required"`
+		Sets   map[string][]string `check:"dive,minlen:1"`
+	}
+	errs := CheckAll(testDive{Plain: []string{""}})
+	assert.Len(t, errs, 3)
+	assert.EqualError(t, errs[0], "value required: Tags")
+	assert.EqualError(t, errs[1], "too short: Tags minlen:2")
+	assert.EqualError(t, errs[2], "too short: Ptr minlen:1")
+
+	errs = CheckAll(&testDive{
+		Tags:   []string{"a", "B1"},
+		Hosts:  map[string]string{"Local": ""},
+		Matrix: [][]int{{0, 2}, {}},
+		Ptr:    &[]string{""},
+		Plain:  []string{"plain"},
+		Sets:   map[string][]string{"a": nil},
+	})
+	assert.Len(t, errs, 6)
+	assert.EqualError(t, errs[0], "no matches: Tags[1] re:^[a-z]+$")
+	assert.EqualError(t, errs[1], "no matches: Hosts[Local] re:^[a-z]+$")
+	assert.EqualError(t, errs[2], "value required: Hosts[Local]")
+	assert.EqualError(t, errs[3], "unexpected value: Matrix[0][1] 2")
+	assert.EqualError(t, errs[4], "too short: Matrix[1] minlen:1")
+	assert.EqualError(t, errs[5], "too short: Sets[a] minlen:1")
+
+	errs = CheckAll(&testDive{
+		Strict: true,
+		Tags:   []string{"a", "b"},
+		Ptr:    &[]string{"", "ptr"},
+		Plain:  []string{"plain"},
+	})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "value required: Ptr[0]")
+
+	assert.NoError(t, Compile(reflect.TypeOf(testDive{})))
+}
+
+func TestCheckDiveInvalid(t *testing.T) {
+	type testInvalid struct {
+		Name   string         `check:"dive,required"`
+		Keys   []string       `check:"dive,keys,required,endkeys"`
+		Open   map[string]int `check:"dive,keys,required"`
+		Extra  string         `check:"required,endkeys"`
+		Length int            `check:"minlen:1"`
+		Arg    string         `check:"minlen:-1"`
+		Elem   []string       `check:"dive,custom"`
+		Trim   map[string]int `check:"dive,keys,required,trim,endkeys"`
+	}
+	errs := CheckAll(testInvalid{Keys: []string{""}, Elem: []string{""}, Trim: map[string]int{" a ": 1}})
+	assert.Len(t, errs, 9)
+	assert.EqualError(t, errs[0], "bad syntax: Name dive")
+	assert.EqualError(t, errs[1], "bad syntax: Keys keys")
+	assert.EqualError(t, errs[2], "bad syntax: Open keys")
+	assert.EqualError(t, errs[3], "value required: Extra")
+	assert.EqualError(t, errs[4], "bad syntax: Extra endkeys")
+	assert.EqualError(t, errs[5], "bad syntax: Length minlen:1")
+	assert.EqualError(t, errs[6], "bad syntax: Arg minlen:-1")
+	assert.EqualError(t, errs[7], "unknown check: custom")
+	//map keys can not be normalized
+	assert.EqualError(t, errs[8], "bad syntax: Trim trim")
+
+	err := Compile(reflect.TypeOf(testInvalid{}))
+	assert.EqualError(t, err, "invalid check tags of checks.testInvalid:\n"+
+		"\tbad syntax: Name dive\n"+
+		"\tbad syntax: Keys keys\n"+
+		"\tbad syntax: Open keys\n"+
+		"\tbad syntax: Extra endkeys\n"+
+		"\tbad syntax: Length minlen:1\n"+
+		"\tbad syntax: Arg minlen:-1\n"+
+		"\tunknown check: Elem custom\n"+
+		"\tbad syntax: Trim trim")
+}
@@ -0,0 +1,85 @@
+package checks
+
+import (
+	"fmt"
+	"reflect"
+)
+
+//Enumer is implemented by enumeration types validating their values.
+//Types declaring the method Values returning the slice of the type itself,
+//e.g. func (l Level) Values() []Level, are validated against the values
+//returned and need not implement Enumer
+type Enumer interface {
+	Valid() bool
+}
+
+var enumerInterface = reflect.TypeOf((*Enumer)(nil)).Elem()
+
+//valuesMethod returns the method Values() []T of the enumeration type T
+//declared with the value receiver
+func valuesMethod(t reflect.Type) (reflect.Method, bool) {
+	method, ok := t.MethodByName("Values")
+	if !ok {
+		return method, false
+	}
+	mt := method.Type
+	return method, mt.NumIn() == 1 && mt.NumOut() == 1 && mt.Out(0).Kind() == reflect.Slice && mt.Out(0).Elem() == t
+}
+
+//enum returns error if the value of the enumeration type is not one of its values.
+//Zero values are not checked, pointers and interfaces are dereferenced
+func enum(field string, value reflect.Value) error {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() || !value.CanInterface() {
+		return nil
+	}
+	t := value.Type()
+	method, values := valuesMethod(t)
+	if !values && !t.Implements(enumerInterface) {
+		return nil
+	}
+	v := value.Interface()
+	if reflect.DeepEqual(v, reflect.Zero(t).Interface()) {
+		return nil
+	}
+	if !values {
+		if v.(Enumer).Valid() {
+			return nil
+		}
+		return newError(ErrValueUnexpected, field, v, ErrorType)
+	}
+	items := value.Method(method.Index).Call(nil)[0]
+	allowed := make([]string, 0, items.Len())
+	for i := 0; i < items.Len(); i++ {
+		item := items.Index(i).Interface()
+		if reflect.DeepEqual(item, v) {
+			return nil
+		}
+		allowed = append(allowed, fmt.Sprintf("%v", item))
+	}
+	err := newError(ErrValueUnexpected, field, v, ErrorType)
+	err.allowed = &allowed
+	return err
+}
+
+//hasExpect reports whether the values of the field are listed by rules,
+//listed values replace the values of the enumeration
+func hasExpect(rules []rule) bool {
+	for _, r := range rules {
+		if r.name == ruleExpect || r.name == ruleIExpect {
+			return true
+		}
+	}
+	return false
+}
+
+//Enum returns error if the value of the field is of the enumeration type
+//and is not one of its values. See Enumer
+func Enum(field string, value interface{}) error {
+	return enum(field, reflect.ValueOf(value))
+}
@@ -0,0 +1,81 @@
+package checks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	testEnumLevel  string
+	testEnumFormat string
+	testNotEnum    int
+
+	testEnums struct {
+		Level   testEnumLevel
+		Levels  []testEnumLevel `check:"dive,required"`
+		Listed  testEnumLevel   `check:"expect:trace"`
+		Format  *testEnumFormat
+		Formats []testEnumFormat
+		Any     interface{}
+		NotEnum testNotEnum
+	}
+)
+
+func (testEnumLevel) Values() []testEnumLevel {
+	return []testEnumLevel{"info", "debug"}
+}
+
+func (f testEnumFormat) Valid() bool {
+	return f == "json"
+}
+
+//Values does not return values of the type
+func (testNotEnum) Values() []int {
+	return []int{1}
+}
+
+func TestEnum(t *testing.T) {
+	json, xml := testEnumFormat("json"), testEnumFormat("xml")
+	assert.NoError(t, Enum("Level", testEnumLevel("")))
+	assert.NoError(t, Enum("Level", testEnumLevel("info")))
+	assert.NoError(t, Enum("Format", (*testEnumFormat)(nil)))
+	assert.NoError(t, Enum("Format", &json))
+	assert.NoError(t, Enum("NotEnum", testNotEnum(2)))
+	assert.NoError(t, Enum("Value", 2))
+	assert.EqualError(t, Enum("Format", &xml), "unexpected value: Format xml")
+
+	err := Enum("Level", testEnumLevel("trace"))
+	assert.EqualError(t, err, "unexpected value: Level trace (allowed: info, debug)")
+	assert.Equal(t, []string{"info", "debug"}, err.(ErrorCheckResult).Allowed())
+
+	_, ok := valuesMethod(reflect.TypeOf(testEnumLevel("")))
+	assert.True(t, ok)
+	_, ok = valuesMethod(reflect.TypeOf(testNotEnum(0)))
+	assert.False(t, ok)
+}
+
+func TestCheckEnums(t *testing.T) {
+	xml := testEnumFormat("xml")
+	v := &testEnums{
+		Level:   "trace",
+		Levels:  []testEnumLevel{"debug", "", "error"},
+		Listed:  "trace",
+		Format:  &xml,
+		Formats: []testEnumFormat{"yaml"},
+		Any:     testEnumLevel("warn"),
+		NotEnum: 3,
+	}
+	errs := New(ModeAll, ErrorAll).Check(v)
+	assert.Len(t, errs, 5)
+	assert.EqualError(t, errs[0], "unexpected value: Level trace (allowed: info, debug)")
+	assert.EqualError(t, errs[1], "value required: Levels[1]")
+	assert.EqualError(t, errs[2], "unexpected value: Levels[2] error (allowed: info, debug)")
+	assert.EqualError(t, errs[3], "unexpected value: Format xml")
+	assert.EqualError(t, errs[4], "unexpected value: Any warn (allowed: info, debug)")
+
+	errs = New(ModeAll, ErrorAll).Check(&testEnums{Listed: "info"})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "unexpected value: Listed info")
+}
@@ -1,6 +1,9 @@
 package checks
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 //Type errors
 const (
@@ -24,7 +27,13 @@ type (
 	ErrorCheckResult struct {
 		ErrorCheck
 		FieldName string
+		Path      string
 		Value     interface{}
+		//Position is the position of the field in the source, see Positions.Attach
+		Position *Position
+
+		subpath string    //path of the nested value relative to the path of the field
+		allowed *[]string //values the field may take, the pointer keeps results comparable
 	}
 )
 
@@ -48,11 +57,57 @@ func (e ErrorCheck) GetType() Type {
 	return e.typ
 }
 
+//Cause returns the error of the check, e.g. ErrValueRequired
+func (e ErrorCheck) Cause() error {
+	return e.cause
+}
+
+func (t Type) String() string {
+	switch t {
+	case ErrorType:
+		return "error"
+	case WarningType:
+		return "warning"
+	case ErrorAll:
+		return "all"
+	}
+	return fmt.Sprintf("Type(%d)", int(t))
+}
+
 func (e ErrorCheckResult) Error() string {
+	return e.format(fmt.Sprintf("%v: %s", e.cause, e.name()))
+}
+
+//Allowed returns the values the field may take, e.g. the values of the enumeration
+func (e ErrorCheckResult) Allowed() []string {
+	if e.allowed == nil {
+		return nil
+	}
+	return *e.allowed
+}
+
+//message returns the text of the result without the path of the field
+func (e ErrorCheckResult) message() string {
+	return e.format(e.cause.Error())
+}
+
+//format appends the value and the allowed values to the text
+func (e ErrorCheckResult) format(text string) string {
 	if e.Value != nil {
-		return fmt.Sprintf("%v: %s %v", e.cause, e.FieldName, e.Value)
+		text = fmt.Sprintf("%s %v", text, e.Value)
+	}
+	if allowed := e.Allowed(); len(allowed) > 0 {
+		text = fmt.Sprintf("%s (allowed: %s)", text, strings.Join(allowed, ", "))
+	}
+	return text
+}
+
+//name returns the path of the field or the name of the field if the path is unknown
+func (e ErrorCheckResult) name() string {
+	if e.Path != "" {
+		return e.Path
 	}
-	return fmt.Sprintf("%v: %s", e.cause, e.FieldName)
+	return e.FieldName
 }
 
 //Filter returns filtred slice error by Type
@@ -22,6 +22,23 @@ func TestErrorCheckResult(t *testing.T) {
 	assert.EqualError(t, got, "test: F1 {}")
 	got.Value = nil
 	assert.EqualError(t, got, "test: F1")
+	assert.Nil(t, got.Allowed())
+	allowed := []string{"a", "b"}
+	got.allowed = &allowed
+	assert.Equal(t, allowed, got.Allowed())
+	assert.EqualError(t, got, "test: F1 (allowed: a, b)")
+}
+
+func TestErrorCheckResultComparable(t *testing.T) {
+	err := Enum("Level", testEnumLevel("trace"))
+	if !assert.Error(t, err) {
+		return
+	}
+	assert.NotEmpty(t, err.(ErrorCheckResult).Allowed())
+	assert.True(t, err == err)
+	seen := map[error]bool{err: true}
+	assert.True(t, seen[err])
+	assert.False(t, err == Enum("Level", testEnumLevel("warn")))
 }
 
 func TestErrorFilter(t *testing.T) {
@@ -0,0 +1,177 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//oneOf reports whether the value equals one of values. Values are compared as strings
+//and parsed into the type of the value, strings are compared ignoring case if fold is true
+func oneOf(value interface{}, values []string, fold bool) bool {
+	text := fmt.Sprintf("%v", value)
+	v := reflect.ValueOf(value)
+	for _, item := range values {
+		if text == item || (fold && strings.EqualFold(text, item)) || equalValue(v, item) {
+			return true
+		}
+	}
+	return false
+}
+
+//equalValue reports whether the value equals the item parsed into the type of the value
+func equalValue(v reflect.Value, item string) bool {
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(item)
+		return err == nil && b == v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, err := strconv.ParseInt(item, 10, 64); err == nil {
+			return n == v.Int()
+		}
+		f, err := strconv.ParseFloat(item, 64)
+		return err == nil && f == float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n, err := strconv.ParseUint(item, 10, 64); err == nil {
+			return n == v.Uint()
+		}
+		f, err := strconv.ParseFloat(item, 64)
+		return err == nil && f == float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(item, v.Type().Bits())
+		return err == nil && f == v.Float()
+	}
+	return false
+}
+
+func expect(ctx context.Context, owner reflect.Value, value reflect.Value, strField *reflect.StructField, r rule) error {
+	value = reflect.Indirect(value)
+	if r.arg == "" || r.arg == "@" {
+		return newError(ErrBadSyntax, strField.Name, r.text, ErrorType)
+	}
+
+	if isNil(value) || !value.IsValid() {
+		return newError(ErrValueUnexpected, strField.Name, "<nil>", ErrorType)
+	}
+	return expectValue(ctx, owner, strField.Name, value.Interface(), r)
+}
+
+//expectValue returns error if the value is not one of the values of the expect rule.
+//Values of expect:@Name are returned by the method of the owner or the registered function
+//and are listed by the error
+func expectValue(ctx context.Context, owner reflect.Value, field string, value interface{}, r rule) error {
+	if !strings.HasPrefix(r.arg, "@") {
+		if r.name == ruleIExpect {
+			return IExpect(field, value, strings.Split(r.arg, ";"))
+		}
+		return Expect(field, value, strings.Split(r.arg, ";"))
+	}
+	values, err := allowedValues(ctx, owner, r.arg[1:])
+	if err != nil {
+		return newError(err, field, r.text, ErrorType)
+	}
+	if oneOf(value, values, r.name == ruleIExpect) {
+		return nil
+	}
+	result := newError(ErrValueUnexpected, field, value, ErrorType)
+	result.allowed = &values
+	return result
+}
+
+//ValuesFunc returns the values allowed by expect:@Name
+type ValuesFunc func() []string
+
+type (
+	valuesResult struct {
+		values []string
+		err    error
+	}
+
+	//valuesCache holds values of registered functions of expect:@Name for the duration
+	//of the check
+	valuesCache struct {
+		mu     sync.Mutex
+		values map[string]valuesResult
+	}
+
+	valuesCacheKey struct{}
+)
+
+var (
+	valuesMu    sync.RWMutex
+	valuesFuncs = map[string]ValuesFunc{}
+)
+
+//RegisterValues registers the function returning the values allowed by expect:@name.
+//Methods of the struct take precedence
+func RegisterValues(name string, fn ValuesFunc) {
+	valuesMu.Lock()
+	defer valuesMu.Unlock()
+	valuesFuncs[name] = fn
+}
+
+func lookupValues(name string) (ValuesFunc, bool) {
+	valuesMu.RLock()
+	defer valuesMu.RUnlock()
+	fn, ok := valuesFuncs[name]
+	return fn, ok
+}
+
+//withValuesCache returns the context caching values of expect:@Name
+func withValuesCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, valuesCacheKey{}, &valuesCache{})
+}
+
+//allowedValues returns the values of expect:@name returned by the method of the owner
+//with the value receiver or by the registered function. Methods are called for each
+//check as their values depend on the owner, values of registered functions are cached
+//for the duration of the check
+func allowedValues(ctx context.Context, owner reflect.Value, name string) ([]string, error) {
+	owner = reflect.Indirect(elem(owner))
+	if owner.IsValid() {
+		if method := owner.MethodByName(name); method.IsValid() {
+			result := methodValues(method)
+			return result.values, result.err
+		}
+	}
+	cache, _ := ctx.Value(valuesCacheKey{}).(*valuesCache)
+	if cache == nil {
+		result := registeredValues(name)
+		return result.values, result.err
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	result, ok := cache.values[name]
+	if !ok {
+		result = registeredValues(name)
+		if cache.values == nil {
+			cache.values = make(map[string]valuesResult)
+		}
+		cache.values[name] = result
+	}
+	return result.values, result.err
+}
+
+//methodValues calls the method returning the slice of values
+func methodValues(method reflect.Value) valuesResult {
+	t := method.Type()
+	if t.NumIn() != 0 || t.NumOut() != 1 || t.Out(0).Kind() != reflect.Slice {
+		return valuesResult{err: ErrWrongSignatureMethod}
+	}
+	items := method.Call(nil)[0]
+	values := make([]string, items.Len())
+	for i := range values {
+		values[i] = fmt.Sprintf("%v", items.Index(i).Interface())
+	}
+	return valuesResult{values: values}
+}
+
+func registeredValues(name string) valuesResult {
+	if fn, ok := lookupValues(name); ok {
+		return valuesResult{values: fn()}
+	}
+	return valuesResult{err: ErrMethodNotFound}
+}
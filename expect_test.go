@@ -0,0 +1,188 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testColor int
+
+func (c testColor) String() string {
+	return [...]string{"red", "green"}[c]
+}
+
+func TestEqualValue(t *testing.T) {
+	cases := []struct {
+		value interface{}
+		item  string
+		want  bool
+	}{
+		{true, "TRUE", true},
+		{false, "0", true},
+		{true, "yes", false},
+		{1, "1.0", true},
+		{1, "1.5", false},
+		{int8(-3), "-3", true},
+		{uint(2), "2.0", true},
+		{uint(2), "-2", false},
+		{1.0, "1", true},
+		{float32(0.1), "0.1", true},
+		{0.1, "0.10", true},
+		{"a", "a", false},
+		{struct{}{}, "{}", false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, equalValue(reflect.ValueOf(c.value), c.item), fmt.Sprintf("%v %s", c.value, c.item))
+	}
+}
+
+func TestOneOf(t *testing.T) {
+	assert.True(t, oneOf("Info", []string{"debug", "info"}, true))
+	assert.False(t, oneOf("Info", []string{"debug", "info"}, false))
+	assert.True(t, oneOf(testColor(1), []string{"green"}, false))
+	assert.True(t, oneOf(testColor(1), []string{"1"}, false))
+	assert.True(t, oneOf(testColor(1), []string{"GREEN"}, true))
+	assert.True(t, oneOf(struct{}{}, []string{"{}"}, false))
+	assert.False(t, oneOf(2.5, []string{"2", "3"}, false))
+}
+
+func TestCheckTypedExpect(t *testing.T) {
+	type testTyped struct {
+		Ratio  float64   `check:"expect:0.5;1.0"`
+		Count  int       `check:"expect:1.0;2"`
+		Strict *bool     `check:"expect:TRUE"`
+		Mode   string    `check:"iexpect:TLS;none"`
+		Color  testColor `check:"iexpect:Green"`
+		Level  *string   `check:"iexpect:info"`
+		Bad    string    `check:"iexpect:"`
+	}
+	strict, level := true, "INFO"
+	errs := New(ModeAll, ErrorAll).Check(&testTyped{Ratio: 1, Count: 1, Strict: &strict, Mode: "tls", Color: 1, Level: &level})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "bad syntax: Bad iexpect:")
+
+	errs = New(ModeAll, ErrorAll).Check(&testTyped{Ratio: 0.25, Count: 3, Mode: "ssl"})
+	assert.Len(t, errs, 7)
+	assert.EqualError(t, errs[0], "unexpected value: Ratio 0.25")
+	assert.EqualError(t, errs[1], "unexpected value: Count 3")
+	assert.EqualError(t, errs[2], "unexpected value: Strict <nil>")
+	assert.EqualError(t, errs[3], "unexpected value: Mode ssl")
+	assert.EqualError(t, errs[4], "unexpected value: Color red")
+	assert.EqualError(t, errs[5], "unexpected value: Level <nil>")
+	assert.EqualError(t, errs[6], "bad syntax: Bad iexpect:")
+}
+
+type testDynamic struct {
+	Driver  string   `check:"expect:@AllowedDrivers"`
+	Drivers []string `check:"dive,iexpect:@AllowedDrivers"`
+	Port    *int     `check:"expect:@Ports"`
+	Plugin  string   `check:"expect:@TestPlugins"`
+	Missing string   `check:"expect:@Missing"`
+	Wrong   string   `check:"expect:@WrongValues"`
+	Empty   string   `check:"expect:@"`
+}
+
+var testDriversCalls int
+
+func (testDynamic) AllowedDrivers() []string {
+	testDriversCalls++
+	return []string{"postgres", "sqlite"}
+}
+
+func (testDynamic) Ports() []int {
+	return []int{80, 443}
+}
+
+func (testDynamic) WrongValues(name string) []string {
+	return nil
+}
+
+func TestDynamicExpect(t *testing.T) {
+	RegisterValues("TestPlugins", func() []string {
+		return []string{"auth"}
+	})
+	RegisterValues("AllowedDrivers", func() []string {
+		return []string{"mysql"}
+	})
+	port := 80
+	testDriversCalls = 0
+	errs := New(ModeAll, ErrorAll).Check(&testDynamic{
+		Driver:  "sqlite",
+		Drivers: []string{"Postgres", "mysql"},
+		Port:    &port,
+		Plugin:  "auth",
+	})
+	assert.Len(t, errs, 4)
+	assert.EqualError(t, errs[0], "unexpected value: Drivers[1] mysql (allowed: postgres, sqlite)")
+	assert.Equal(t, []string{"postgres", "sqlite"}, errs[0].(ErrorCheckResult).Allowed())
+	assert.EqualError(t, errs[1], "method not found: Missing expect:@Missing")
+	assert.EqualError(t, errs[2], "wrong signature method: Wrong expect:@WrongValues")
+	assert.EqualError(t, errs[3], "bad syntax: Empty expect:@")
+	//methods are called for each value
+	assert.Equal(t, 3, testDriversCalls)
+
+	port = 8080
+	errs = New(ModeAll, ErrorAll).Check(&testDynamic{Driver: "mysql", Port: &port, Plugin: "cache"})
+	assert.Len(t, errs, 6)
+	assert.EqualError(t, errs[0], "unexpected value: Driver mysql (allowed: postgres, sqlite)")
+	assert.EqualError(t, errs[1], "unexpected value: Port 8080 (allowed: 80, 443)")
+	assert.EqualError(t, errs[2], "unexpected value: Plugin cache (allowed: auth)")
+	assert.Equal(t, 4, testDriversCalls)
+}
+
+type testAllowedItem struct {
+	Allowed []string
+	Value   string `check:"expect:@Values"`
+}
+
+func (i testAllowedItem) Values() []string {
+	return i.Allowed
+}
+
+func TestDynamicExpectInstances(t *testing.T) {
+	type testAllowedList struct {
+		Items []testAllowedItem
+	}
+	errs := New(ModeAll, ErrorAll).Check(&testAllowedList{Items: []testAllowedItem{
+		{Allowed: []string{"x"}, Value: "x"},
+		{Allowed: []string{"y"}, Value: "y"},
+		{Allowed: []string{"y"}, Value: "x"},
+	}})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "unexpected value: Items[2].Value x (allowed: y)")
+}
+
+func TestAllowedValues(t *testing.T) {
+	RegisterValues("TestAllowed", func() []string {
+		return []string{"a"}
+	})
+	values, err := allowedValues(context.Background(), reflect.Value{}, "TestAllowed")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, values)
+
+	_, err = allowedValues(context.Background(), reflect.ValueOf(&testDynamic{}), "Missing")
+	assert.Equal(t, ErrMethodNotFound, err)
+
+	calls := 0
+	RegisterValues("TestAllowedCalls", func() []string {
+		calls++
+		return []string{"b"}
+	})
+	ctx := withValuesCache(context.Background())
+	testDriversCalls = 0
+	for i := 0; i < 2; i++ {
+		values, err = allowedValues(ctx, reflect.ValueOf(&testDynamic{}), "AllowedDrivers")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"postgres", "sqlite"}, values)
+		values, err = allowedValues(ctx, reflect.ValueOf(&testDynamic{}), "TestAllowedCalls")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b"}, values)
+	}
+	//values of registered functions are cached, methods are called each time
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 2, testDriversCalls)
+}
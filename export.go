@@ -0,0 +1,254 @@
+package checks
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+//Defaults of exports
+const (
+	defaultExportName = "go-checks"
+	sarifVersion      = "2.1.0"
+	sarifSchema       = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifInfo         = "https://github.com/arteev/go-checks"
+)
+
+type (
+	//ExportOption configures exports of the report
+	ExportOption func(e *export)
+
+	export struct {
+		name string
+		file string
+	}
+)
+
+//ExportName sets the name of the tool in SARIF and of the test suite in JUnit XML,
+//go-checks by default
+func ExportName(name string) ExportOption {
+	return func(e *export) {
+		e.name = name
+	}
+}
+
+//ExportFile sets the file checked, e.g. the path of the config file relative to the root
+//of the repository. Results of SARIF have physical locations in the file only if it is set,
+//regions of locations are the positions of results, see Positions.Attach
+func ExportFile(file string) ExportOption {
+	return func(e *export) {
+		e.file = file
+	}
+}
+
+func newExport(opts []ExportOption) *export {
+	e := &export{name: defaultExportName}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+//ruleID returns the identifier of the rule of the result, e.g. value-required
+func ruleID(cause string) string {
+	return strings.Join(strings.Fields(cause), "-")
+}
+
+type (
+	sarifLog struct {
+		Version string     `json:"version"`
+		Schema  string     `json:"$schema"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		RuleIndex int             `json:"ruleIndex"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations,omitempty"`
+	}
+
+	sarifLocation struct {
+		PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+		LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+	}
+
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           *sarifRegion          `json:"region,omitempty"`
+	}
+
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn"`
+	}
+
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+
+	sarifLogicalLocation struct {
+		FullyQualifiedName string `json:"fullyQualifiedName"`
+		Kind               string `json:"kind"`
+	}
+)
+
+//WriteSARIF writes results as the SARIF 2.1.0 log. Rules are the causes of results,
+//levels are the types of results and logical locations are the paths of fields
+func (r *Report) WriteSARIF(w io.Writer, opts ...ExportOption) error {
+	e := newExport(opts)
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           e.name,
+			InformationURI: sarifInfo,
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	rules := make(map[string]int)
+	for i, g := range r.ByRule() {
+		id := ruleID(g.Key)
+		rules[g.Key] = i
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               id,
+			ShortDescription: sarifMessage{Text: g.Key},
+		})
+	}
+	for _, err := range r.results {
+		cause := err.Error()
+		var (
+			location sarifLocation
+			region   *sarifRegion
+		)
+		if are, ok := err.(ErrorCheckResult); ok {
+			cause = are.Cause().Error()
+			location.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: are.name(), Kind: "member"}}
+			if are.Position != nil {
+				region = &sarifRegion{StartLine: are.Position.Line, StartColumn: are.Position.Column}
+			}
+		}
+		if e.file != "" {
+			location.PhysicalLocation = &sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: e.file},
+				Region:           region,
+			}
+		}
+		result := sarifResult{
+			RuleID:    ruleID(cause),
+			RuleIndex: rules[cause],
+			Level:     typeOf(err).String(),
+			Message:   sarifMessage{Text: err.Error()},
+		}
+		if location.PhysicalLocation != nil || location.LogicalLocations != nil {
+			result.Locations = []sarifLocation{location}
+		}
+		run.Results = append(run.Results, result)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{run},
+	})
+}
+
+type (
+	junitSuites struct {
+		XMLName xml.Name     `xml:"testsuites"`
+		Suites  []junitSuite `xml:"testsuite"`
+	}
+
+	junitSuite struct {
+		Name     string      `xml:"name,attr"`
+		Tests    int         `xml:"tests,attr"`
+		Failures int         `xml:"failures,attr"`
+		Cases    []junitCase `xml:"testcase"`
+	}
+
+	junitCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		File      string        `xml:"file,attr,omitempty"`
+		Failure   *junitFailure `xml:"failure"`
+		SystemOut string        `xml:"system-out,omitempty"`
+	}
+
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+		Type    string `xml:"type,attr"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+//WriteJUnit writes results as the JUnit XML document having the test case per path of fields.
+//Test cases having errors fail, warnings are written to the output of test cases.
+//The report without results has the single passed test case
+func (r *Report) WriteJUnit(w io.Writer, opts ...ExportOption) error {
+	e := newExport(opts)
+	suite := junitSuite{Name: e.name}
+	for _, g := range r.ByPath() {
+		c := junitCase{Name: g.Key, ClassName: e.name, File: e.file}
+		if c.Name == "" {
+			c.Name = e.name
+		}
+		var errs, warnings []string
+		for _, err := range g.Results {
+			if typeOf(err) == WarningType {
+				warnings = append(warnings, err.Error())
+				continue
+			}
+			errs = append(errs, err.Error())
+		}
+		if len(errs) > 0 {
+			c.Failure = &junitFailure{
+				Message: errs[0],
+				Type:    ErrorType.String(),
+				Text:    strings.Join(errs, "\n"),
+			}
+			suite.Failures++
+		}
+		c.SystemOut = strings.Join(warnings, "\n")
+		suite.Cases = append(suite.Cases, c)
+	}
+	if len(suite.Cases) == 0 {
+		suite.Cases = append(suite.Cases, junitCase{Name: e.name, ClassName: e.name, File: e.file})
+	}
+	suite.Tests = len(suite.Cases)
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitSuites{Suites: []junitSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
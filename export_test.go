@@ -0,0 +1,108 @@
+package checks
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func exportReport() *Report {
+	return NewReport([]error{
+		newError(ErrValueRequired, "Listen", nil, ErrorType),
+		newError(ErrDeprecated, "Timeout", nil, WarningType),
+		newError(ErrNoMatch, "Listen", "re:[0-9]+", ErrorType),
+		errors.New("other"),
+	})
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, exportReport().WriteSARIF(&b, ExportName("config"), ExportFile("config.json")))
+	assert.JSONEq(t, `{
+  "version": "2.1.0",
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "runs": [{
+    "tool": {"driver": {
+      "name": "config",
+      "informationUri": "https://github.com/arteev/go-checks",
+      "rules": [
+        {"id": "value-required", "shortDescription": {"text": "value required"}},
+        {"id": "deprecated-parameter", "shortDescription": {"text": "deprecated parameter"}},
+        {"id": "no-matches", "shortDescription": {"text": "no matches"}},
+        {"id": "other", "shortDescription": {"text": "other"}}
+      ]
+    }},
+    "results": [
+      {"ruleId": "value-required", "ruleIndex": 0, "level": "error",
+       "message": {"text": "value required: Listen"},
+       "locations": [{
+         "physicalLocation": {"artifactLocation": {"uri": "config.json"}},
+         "logicalLocations": [{"fullyQualifiedName": "Listen", "kind": "member"}]
+       }]},
+      {"ruleId": "deprecated-parameter", "ruleIndex": 1, "level": "warning",
+       "message": {"text": "deprecated parameter: Timeout"},
+       "locations": [{
+         "physicalLocation": {"artifactLocation": {"uri": "config.json"}},
+         "logicalLocations": [{"fullyQualifiedName": "Timeout", "kind": "member"}]
+       }]},
+      {"ruleId": "no-matches", "ruleIndex": 2, "level": "error",
+       "message": {"text": "no matches: Listen re:[0-9]+"},
+       "locations": [{
+         "physicalLocation": {"artifactLocation": {"uri": "config.json"}},
+         "logicalLocations": [{"fullyQualifiedName": "Listen", "kind": "member"}]
+       }]},
+      {"ruleId": "other", "ruleIndex": 3, "level": "error",
+       "message": {"text": "other"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "config.json"}}}]}
+    ]
+  }]
+}`, b.String())
+
+	b.Reset()
+	assert.NoError(t, NewReport(nil).WriteSARIF(&b))
+	assert.JSONEq(t, `{
+  "version": "2.1.0",
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "runs": [{
+    "tool": {"driver": {"name": "go-checks", "informationUri": "https://github.com/arteev/go-checks", "rules": []}},
+    "results": []
+  }]
+}`, b.String())
+
+	b.Reset()
+	assert.NoError(t, NewReport([]error{errors.New("other")}).WriteSARIF(&b))
+	assert.Contains(t, b.String(), `"message": {`)
+	assert.NotContains(t, b.String(), "locations")
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, exportReport().WriteJUnit(&b, ExportName("config"), ExportFile("config.json")))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="config" tests="3" failures="2">
+    <testcase name="Listen" classname="config" file="config.json">
+      <failure message="value required: Listen" type="error">value required: Listen&#xA;no matches: Listen re:[0-9]+</failure>
+    </testcase>
+    <testcase name="Timeout" classname="config" file="config.json">
+      <system-out>deprecated parameter: Timeout</system-out>
+    </testcase>
+    <testcase name="config" classname="config" file="config.json">
+      <failure message="other" type="error">other</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, b.String())
+
+	b.Reset()
+	assert.NoError(t, NewReport(nil).WriteJUnit(&b))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="go-checks" tests="1" failures="0">
+    <testcase name="go-checks" classname="go-checks"></testcase>
+  </testsuite>
+</testsuites>
+`, b.String())
+}
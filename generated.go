@@ -0,0 +1,334 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+)
+
+type (
+	//GeneratedFunc is the reflection-free check function written by checksgen
+	GeneratedFunc func(c *Collector, v interface{}) bool
+
+	//Collector accumulates check results according to the mode of the checker
+	Collector struct {
+		checker    *SimpeChecker
+		ctx        context.Context
+		mode       Mode
+		errorMode  Type
+		limit      int
+		bail       bool
+		skipAll    bool
+		groups     map[string]bool
+		unexported Unexported
+		result     []error
+		skip       bool
+		skipped    bool
+		path       []segment
+	}
+)
+
+var (
+	generatedMu sync.RWMutex
+	generated   = map[reflect.Type]GeneratedFunc{}
+)
+
+//Register registers generated check function for the type of v
+func Register(v interface{}, fn GeneratedFunc) {
+	generatedMu.Lock()
+	defer generatedMu.Unlock()
+	generated[reflect.TypeOf(v)] = fn
+}
+
+func lookupGenerated(v interface{}) (GeneratedFunc, bool) {
+	generatedMu.RLock()
+	defer generatedMu.RUnlock()
+	fn, ok := generated[reflect.TypeOf(v)]
+	return fn, ok
+}
+
+func (c *SimpeChecker) newCollector(ctx context.Context) *Collector {
+	return &Collector{
+		checker:    c,
+		ctx:        ctx,
+		mode:       c.mode,
+		errorMode:  c.errorMode,
+		limit:      c.limit,
+		bail:       c.bail,
+		skipAll:    c.skipAll,
+		groups:     c.groups,
+		unexported: c.unexported,
+		result:     make([]error, 0),
+	}
+}
+
+//Add adds results of the checks of a single value.
+//Returns false when checking must be stopped
+func (c *Collector) Add(errs ...error) bool {
+	c.skipped = false
+	if len(errs) == 0 {
+		return true
+	}
+	if errs[0] == ErrSkip {
+		if c.skipAll {
+			c.skip = true
+			return false
+		}
+		c.skipped = true
+		return true
+	}
+	if len(c.path) > 0 {
+		errs = withPath(errs, c.Path)
+	}
+	for _, e := range errs {
+		if !acceptType(e, c.errorMode) {
+			continue
+		}
+		c.result = append(c.result, e)
+		if c.mode == ModeFirst || (c.limit > 0 && len(c.result) >= c.limit) {
+			return false
+		}
+		if c.bail {
+			break
+		}
+	}
+	return true
+}
+
+//Skipped reports whether the value added last returned ErrSkip.
+//Nested values of the skipped value must not be checked
+func (c *Collector) Skipped() bool {
+	return c.skipped
+}
+
+//Normalize normalizes the value held by the interface before its checks.
+//Types of such values are not known to generated code
+func (c *Collector) Normalize(v interface{}) {
+	if value := reflect.ValueOf(v); value.IsValid() && normalizable(value.Type()) {
+		normalizeValue(value, nil, c.unexported)
+	}
+}
+
+//Nested checks nested values of the value held by the interface or of the type declared
+//in other package using reflection. Returns false when checking must be stopped
+func (c *Collector) Nested(v interface{}) bool {
+	iter := newIterator(v, c.unexported)
+	if !iter.HasNext() {
+		return true
+	}
+	prefix := c.Path()
+	iter.next()
+	for iter.HasNext() {
+		item := iter.next()
+		path := func() string {
+			return joinPath(prefix, item.Path())
+		}
+		if !c.Add(withPath(c.checker.checkValue(c.ctx, item, item.owner), path)...) {
+			return false
+		}
+		if c.Skipped() {
+			iter.skipChildren()
+		}
+	}
+	return true
+}
+
+//Embedded checks nested values of the embedded field of the type declared in other package
+//using reflection. v is the pointer to the field. Fields of embedded structs are promoted.
+//Returns false when checking must be stopped
+func (c *Collector) Embedded(field string, v interface{}) bool {
+	if t := reflect.TypeOf(v).Elem(); t.Kind() == reflect.Struct {
+		return c.Nested(v)
+	}
+	c.EnterField(field)
+	defer c.Leave()
+	return c.Nested(v)
+}
+
+//Call calls the method of the value or the registered function of the call check
+//of the tag using reflection. ptr is the pointer to the checked value
+func (c *Collector) Call(field string, tag string, ptr interface{}) []error {
+	r := parseRule(tag)
+	call, ok := parseCall(r.arg)
+	if !ok {
+		return []error{newError(ErrBadSyntax, field, r.text, ErrorType)}
+	}
+	value := reflect.ValueOf(ptr).Elem()
+	method, owner := lookupCall(reflect.Value{}, value, call.name)
+	if !method.IsValid() {
+		return []error{fmt.Errorf("method not found: %s", call.name)}
+	}
+	return callMethod(c.ctx, method, owner, Field{Name: field, Path: c.Path()}, value, r, call)
+}
+
+//ExpectFrom returns error if the value of the field is not one of the values of
+//expect:@Name or iexpect:@Name of the tag. owner is the struct holding the field
+func (c *Collector) ExpectFrom(field string, tag string, owner interface{}, value interface{}) error {
+	return expectValue(c.ctx, reflect.ValueOf(owner), field, value, parseRule(tag))
+}
+
+//Context returns the context of the check
+func (c *Collector) Context() context.Context {
+	return c.ctx
+}
+
+//Unexported returns the policy of checking unexported fields
+func (c *Collector) Unexported() Unexported {
+	return c.unexported
+}
+
+//InGroup reports whether one of groups is active
+func (c *Collector) InGroup(groups []string) bool {
+	return inGroup(c.groups, groups)
+}
+
+//acceptType reports whether the type of the error is accepted by the error mode
+func acceptType(e error, errorMode Type) bool {
+	if are, ok := e.(ErrorCheckResult); ok {
+		typ := are.GetType()
+		return (typ & errorMode) == typ
+	}
+	return true
+}
+
+//Result returns collected errors
+func (c *Collector) Result() []error {
+	if c.skip || len(c.result) == 0 {
+		return nil
+	}
+	return c.result
+}
+
+//Append appends non-nil errors to errs
+func Append(errs []error, err ...error) []error {
+	for _, e := range err {
+		if e != nil {
+			errs = append(errs, e)
+		}
+	}
+	return errs
+}
+
+//NewError returns new check result
+func NewError(err error, field string, value interface{}, typ Type) ErrorCheckResult {
+	return newError(err, field, value, typ)
+}
+
+//IsZero reports whether v is the zero value in terms of the required check
+func IsZero(v interface{}) bool {
+	value := reflect.ValueOf(v)
+	return isNil(value) || !value.IsValid() || isZero(value)
+}
+
+//CallChecker calls Check if v implements Checker
+func CallChecker(v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	check, ok := v.(Checker)
+	if !ok {
+		return nil
+	}
+	return check.Check()
+}
+
+//Required returns error if value of the field is zero
+func Required(field string, zero bool) error {
+	if zero {
+		return newError(ErrValueRequired, field, nil, ErrorType)
+	}
+	return nil
+}
+
+//Deprecated returns warning if value of the field is not zero
+func Deprecated(field string, zero bool) error {
+	if zero {
+		return nil
+	}
+	return newError(ErrDeprecated, field, nil, WarningType)
+}
+
+//Expect returns error if value of the field is not one of values.
+//Values are parsed into the type of the value for comparison
+func Expect(field string, value interface{}, values []string) error {
+	if !oneOf(value, values, false) {
+		return newError(ErrValueUnexpected, field, value, ErrorType)
+	}
+	return nil
+}
+
+//IExpect is like Expect but compares strings ignoring case
+func IExpect(field string, value interface{}, values []string) error {
+	if !oneOf(value, values, true) {
+		return newError(ErrValueUnexpected, field, value, ErrorType)
+	}
+	return nil
+}
+
+//OneOf reports whether value is one of values
+func OneOf(value interface{}, values []string) bool {
+	return hasValue(fmt.Sprintf("%v", value), values)
+}
+
+//MinLen returns error if length of the value of the field is less than min
+func MinLen(field string, tag string, min int, length int) error {
+	if length < min {
+		return newError(ErrTooShort, field, tag, ErrorType)
+	}
+	return nil
+}
+
+//Match returns error if value of the field does not match re.
+//See MatchText for the text of the value
+func Match(field string, tag string, re *regexp.Regexp, value interface{}) error {
+	if !re.MatchString(MatchText(value)) {
+		return newError(ErrNoMatch, field, tag, ErrorType)
+	}
+	return nil
+}
+
+//NotMatch returns error if value of the field matches re
+func NotMatch(field string, tag string, re *regexp.Regexp, value interface{}) error {
+	if re.MatchString(MatchText(value)) {
+		return newError(ErrMatch, field, tag, ErrorType)
+	}
+	return nil
+}
+
+//MatchRule returns error if value of the field does not match the regular expression
+//of the re or match rule of the tag or matches the regular expression of the negated rule.
+//The regular expression is looked up at run time, e.g. re:@name
+func MatchRule(field string, tag string, value interface{}) error {
+	r := parseRule(tag)
+	re, err := r.regexp()
+	if err != nil {
+		return newError(err, field, r.text, ErrorType)
+	}
+	if r.negated() {
+		return NotMatch(field, r.text, re, value)
+	}
+	return Match(field, r.text, re, value)
+}
+
+//MatchText returns the text of the value matched by regular expressions: the result
+//of String of fmt.Stringer and Error of error, the content of []byte, otherwise
+//the value formatted by fmt with the %v verb. Pointers are dereferenced
+func MatchText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	case error:
+		return v.Error()
+	case []byte:
+		return string(v)
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		return MatchText(v.Elem().Interface())
+	}
+	return fmt.Sprintf("%v", value)
+}
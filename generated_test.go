@@ -0,0 +1,125 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollector(t *testing.T) {
+	err := errors.New("test")
+	warning := newError(err, "f1", nil, WarningType)
+
+	c := New(ModeAll, ErrorType).newCollector(context.Background())
+	assert.True(t, c.Add())
+	assert.True(t, c.Add(err, warning))
+	assert.Equal(t, []error{err}, c.Result())
+
+	c = New(ModeFirst, ErrorAll).newCollector(context.Background())
+	assert.False(t, c.Add(warning, err))
+	assert.Equal(t, []error{warning}, c.Result())
+
+	c = New(ModeAll, ErrorAll).newCollector(context.Background())
+	assert.True(t, c.Add(err))
+	assert.True(t, c.Add(ErrSkip, err))
+	assert.True(t, c.Skipped())
+	assert.True(t, c.Add())
+	assert.False(t, c.Skipped())
+	assert.Equal(t, []error{err}, c.Result())
+
+	c = New(ModeAll, ErrorAll, WithSkipAll()).newCollector(context.Background())
+	assert.True(t, c.Add(err))
+	assert.False(t, c.Add(ErrSkip, err))
+	assert.Nil(t, c.Result())
+
+	c = New(ModeAll, ErrorAll).newCollector(context.Background())
+	assert.Nil(t, c.Result())
+}
+
+func TestHelpers(t *testing.T) {
+	assert.Nil(t, Append(nil, nil, nil))
+	assert.Len(t, Append(nil, nil, ErrSkip), 1)
+
+	assert.NoError(t, CallChecker(nil))
+	assert.NoError(t, CallChecker("str"))
+	assert.EqualError(t, CallChecker(testNestedChecker{Error: errors.New("err1")}), "err1")
+
+	assert.True(t, IsZero(nil))
+	assert.True(t, IsZero(struct{ A int }{}))
+	assert.False(t, IsZero(1))
+
+	assert.NoError(t, Required("F", false))
+	assert.EqualError(t, Required("F", true), "value required: F")
+	assert.NoError(t, Deprecated("F", true))
+	assert.EqualError(t, Deprecated("F", false), "deprecated parameter: F")
+
+	assert.NoError(t, Expect("F", 1, []string{"1", "2"}))
+	assert.EqualError(t, Expect("F", 3, []string{"1", "2"}), "unexpected value: F 3")
+
+	re := regexp.MustCompile("[a-z]+")
+	assert.NoError(t, Match("F", "re:[a-z]+", re, "abc"))
+	assert.EqualError(t, Match("F", "re:[a-z]+", re, 123), "no matches: F re:[a-z]+")
+	assert.NoError(t, NotMatch("F", "!re:[a-z]+", re, "123"))
+	assert.EqualError(t, NotMatch("F", "!re:[a-z]+", re, "a1"), "unexpected match: F !re:[a-z]+")
+}
+
+func TestMatchText(t *testing.T) {
+	text := "text"
+	assert.Equal(t, "text", MatchText(text))
+	assert.Equal(t, "text", MatchText(&text))
+	assert.Equal(t, "text", MatchText([]byte("text")))
+	assert.Equal(t, "text", MatchText(testMatchText(1)))
+	assert.Equal(t, "text", MatchText(errors.New("text")))
+	assert.Equal(t, "12", MatchText(12))
+	assert.Equal(t, "<nil>", MatchText(nil))
+	assert.Equal(t, "[a b]", MatchText([]string{"a", "b"}))
+}
+
+func TestCheckerGenerated(t *testing.T) {
+	type testGenerated struct {
+		Value string `check:"required"`
+	}
+	Register((*testGenerated)(nil), func(c *Collector, v interface{}) bool {
+		return c.Add(errors.New("generated"))
+	})
+
+	err := New(ModeFirst, ErrorType).Check(&testGenerated{})
+	assert.EqualError(t, err[0], "value required: Value")
+
+	err = New(ModeFirst, ErrorType, WithGenerated()).Check(&testGenerated{})
+	assert.EqualError(t, err[0], "generated")
+
+	err = New(ModeFirst, ErrorType, WithGenerated()).Check((*testGenerated)(nil))
+	assert.Nil(t, err)
+
+	err = New(ModeFirst, ErrorType, WithGenerated()).Check(testGenerated{})
+	assert.EqualError(t, err[0], "value required: Value")
+}
+
+func TestCollectorNested(t *testing.T) {
+	type testPlugin struct {
+		Enabled bool
+		Name    string `check:"required,if:Enabled"`
+	}
+	c := New(ModeAll, ErrorAll).newCollector(context.Background())
+	c.EnterField("Plugins")
+	c.EnterIndex(1)
+	assert.True(t, c.Nested(nil))
+	assert.True(t, c.Nested((*testPlugin)(nil)))
+	assert.True(t, c.Nested(&testPlugin{Enabled: true}))
+	assert.True(t, c.Nested([]interface{}{&testPlugin{Enabled: true, Name: "name"}, testPlugin{Enabled: true}}))
+	c.Leave()
+	c.Leave()
+
+	errs := c.Result()
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "value required: Plugins[1].Name")
+	assert.EqualError(t, errs[1], "value required: Plugins[1][1].Name")
+
+	c = New(ModeFirst, ErrorAll).newCollector(context.Background())
+	assert.False(t, c.Nested(&testPlugin{Enabled: true}))
+	assert.Len(t, c.Result(), 1)
+}
@@ -0,0 +1,43 @@
+package checks
+
+import "strings"
+
+//WithGroups sets active groups of the checker. Rules of the fields with the `group`
+//rule are checked only if one of the groups of the field is active
+func WithGroups(groups ...string) Option {
+	return func(c *SimpeChecker) {
+		c.groups = make(map[string]bool, len(groups))
+		for _, group := range groups {
+			c.groups[group] = true
+		}
+	}
+}
+
+//inGroup reports whether one of groups is active
+func inGroup(active map[string]bool, groups []string) bool {
+	for _, group := range groups {
+		if active[group] {
+			return true
+		}
+	}
+	return false
+}
+
+//groups reports whether rules of the field must be checked for active groups.
+//Fields without the `group` rule are always checked
+func groups(active map[string]bool, field string, rules []rule) (bool, error) {
+	var found bool
+	for _, r := range rules {
+		if r.name != ruleGroup {
+			continue
+		}
+		if r.arg == "" {
+			return false, newError(ErrBadSyntax, field, r.text, ErrorType)
+		}
+		if inGroup(active, strings.Split(r.arg, ";")) {
+			return true, nil
+		}
+		found = true
+	}
+	return !found, nil
+}
@@ -0,0 +1,54 @@
+package checks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInGroup(t *testing.T) {
+	active := map[string]bool{"create": true}
+	assert.True(t, inGroup(active, []string{"update", "create"}))
+	assert.False(t, inGroup(active, []string{"update"}))
+	assert.False(t, inGroup(nil, []string{"create"}))
+	assert.False(t, inGroup(active, nil))
+}
+
+func TestCheckGroups(t *testing.T) {
+	type testUser struct {
+		ID       string `check:"group:update,required"`
+		Name     string `check:"required"`
+		Password string `check:"group:create;update,required"`
+		Email    string `check:"group:create,required,group:invite"`
+	}
+	errs := CheckAll(testUser{})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "value required: Name")
+
+	errs = New(ModeAll, ErrorType, WithGroups("create")).Check(testUser{Name: "name"})
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "value required: Password")
+	assert.EqualError(t, errs[1], "value required: Email")
+
+	errs = New(ModeAll, ErrorType, WithGroups("update")).Check(testUser{Name: "name"})
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "value required: ID")
+	assert.EqualError(t, errs[1], "value required: Password")
+
+	errs = New(ModeAll, ErrorType, WithGroups("invite", "dry-run")).Check(&testUser{Name: "name"})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "value required: Email")
+
+	type testInvalid struct {
+		Empty string `check:"group:,required"`
+	}
+	errs = New(ModeAll, ErrorType, WithGroups("create")).Check(testInvalid{})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "bad syntax: Empty group:")
+
+	err := Compile(reflect.TypeOf(testInvalid{}))
+	assert.EqualError(t, err, "invalid check tags of checks.testInvalid:\n"+
+		"\tbad syntax: Empty group:")
+	assert.NoError(t, Compile(reflect.TypeOf(testUser{})))
+}
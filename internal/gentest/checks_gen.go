@@ -0,0 +1,1445 @@
+// Code generated by checksgen; DO NOT EDIT.
+
+package gentest
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+
+	"github.com/arteev/go-checks"
+)
+
+var (
+	_checks_values_0  = []string{"info", "debug", "error", ""}
+	_checks_values_1  = []string{"80", "443"}
+	_checks_re_2      = regexp.MustCompile("[a-z]+")
+	_checks_re_3      = regexp.MustCompile("^[0-9]+$")
+	_checks_values_4  = []string{"dev", "prod"}
+	_checks_re_5      = regexp.MustCompile("^[a-z ]+$")
+	_checks_re_6      = regexp.MustCompile("^[A-Z]+$")
+	_checks_values_7  = []string{"0.1", "1.0"}
+	_checks_values_8  = []string{"1.0", "2"}
+	_checks_values_9  = []string{"FALSE"}
+	_checks_values_10 = []string{"HTTP", "https"}
+	_checks_values_11 = []string{"Info"}
+	_checks_values_12 = []string{"1", "7"}
+	_checks_re_13     = regexp.MustCompile("[0-9]")
+	_checks_re_14     = regexp.MustCompile("^(?:[a-z]+)$")
+	_checks_re_15     = regexp.MustCompile("^(?:admin|root)$")
+	_checks_re_16     = regexp.MustCompile("^(?:[0-9.]+)$")
+	_checks_re_17     = regexp.MustCompile("^ ")
+	_checks_re_18     = regexp.MustCompile("^(?:[a-z][a-z0-9-]*)$")
+	_checks_re_19     = regexp.MustCompile("^(?:[a-z][a-z0-9-]*)$")
+	_checks_re_20     = regexp.MustCompile("[a-z][a-z0-9-]*")
+	_checks_re_21     = regexp.MustCompile("^(?:@[a-z]+)$")
+	_checks_values_22 = []string{"mtls"}
+	_checks_values_23 = []string{"tls", "mtls"}
+	_checks_values_24 = []string{"0"}
+	_checks_values_25 = []string{"local"}
+	_checks_values_26 = []string{"update"}
+	_checks_values_27 = []string{"yes"}
+	_checks_values_28 = []string{"create", "update", "dry"}
+	_checks_values_29 = []string{"update"}
+	_checks_re_30     = regexp.MustCompile("^[a-z]+$")
+	_checks_re_31     = regexp.MustCompile("^[a-z]+$")
+	_checks_values_32 = []string{"0", "1"}
+	_checks_re_33     = regexp.MustCompile("^[a-z]+:[0-9]+$")
+	_checks_values_34 = []string{"1", "2", "3"}
+	_checks_values_35 = []string{"admin"}
+)
+
+func _checks_Config(c *checks.Collector, v *Config) bool {
+	var err error
+	var errs []error
+	c.EnterField("Listen")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Required("Listen", v.Listen == ""))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("LogLevel")
+	errs = errs[:0]
+	err = checks.CallChecker(v.LogLevel)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		errs = checks.Append(errs, checks.Required("LogLevel", v.LogLevel == ""))
+		errs = checks.Append(errs, checks.Expect("LogLevel", v.LogLevel, _checks_values_0))
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Timeout")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Deprecated("Timeout", v.Timeout == 0))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Port")
+	errs = errs[:0]
+	err = nil
+	if v.Port != nil {
+		err = checks.CallChecker(v.Port)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		errs = checks.Append(errs, checks.Required("Port", v.Port == nil))
+		if v.Port == nil {
+			errs = append(errs, checks.NewError(checks.ErrValueUnexpected, "Port", "<nil>", checks.ErrorType))
+		} else {
+			errs = checks.Append(errs, checks.Expect("Port", *v.Port, _checks_values_1))
+		}
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("ValueForFunc")
+	errs = errs[:0]
+	errs = checks.Append(errs, v.ValueCheck("ValueForFunc", v.ValueForFunc))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("ValueRegexp")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Match("ValueRegexp", "re:[a-z]+", _checks_re_2, v.ValueRegexp))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("PtrRegexp")
+	errs = errs[:0]
+	err = nil
+	if v.PtrRegexp != nil {
+		err = checks.CallChecker(v.PtrRegexp)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		if v.PtrRegexp == nil {
+			errs = append(errs, checks.NewError(checks.ErrValueUnexpected, "PtrRegexp", "<nil>", checks.ErrorType))
+		} else {
+			errs = checks.Append(errs, checks.Match("PtrRegexp", "re:^[0-9]+$", _checks_re_3, v.PtrRegexp))
+		}
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("BadRegexp")
+	errs = errs[:0]
+	errs = append(errs, checks.NewError(errors.New("error parsing regexp: missing closing ]: `[a-z`"), "BadRegexp", "re:[a-z", checks.ErrorType))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("BadSyntax")
+	errs = errs[:0]
+	errs = append(errs, checks.NewError(checks.ErrBadSyntax, "BadSyntax", "expect:", checks.ErrorType))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("NotFound")
+	errs = errs[:0]
+	errs = checks.Append(errs, c.Call("NotFound", "call:NotFound", &v.NotFound)...)
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("WrongResult")
+	errs = errs[:0]
+	errs = append(errs, checks.NewError(checks.ErrWrongSignatureMethod, "WrongResult", "call:Wrong", checks.ErrorType))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Region")
+	errs = errs[:0]
+	errs = checks.Append(errs, v.RegionCheck(c.Context(), checks.Field{Name: "Region", Path: c.Path()}, v.Region)...)
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Comment")
+	errs = errs[:0]
+	errs = checks.Append(errs, v.Short(v.Comment))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Retries")
+	for i0 := range v.Retries {
+		c.EnterIndex(i0)
+		errs = errs[:0]
+		errs = checks.Append(errs, v.RetryCheck(checks.Field{Name: "Retries", Path: c.Path()}, v.Retries[i0]))
+		if !c.Add(errs...) {
+			return false
+		}
+		c.Leave()
+	}
+	c.Leave()
+	c.EnterField("Verbosity")
+	errs = errs[:0]
+	err = checks.CallChecker(v.Verbosity)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		errs = checks.Append(errs, v.Verbosity.Valid("Verbosity"))
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("WrongArgs")
+	errs = errs[:0]
+	errs = append(errs, checks.NewError(checks.ErrWrongSignatureMethod, "WrongArgs", "call:TwoNames", checks.ErrorType))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Retry")
+	errs = errs[:0]
+	errs = checks.Append(errs, v.Range("Retry", v.Retry, 1, 3))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("BadArg")
+	errs = errs[:0]
+	errs = append(errs, checks.NewError(checks.ErrBadSyntax, "BadArg", "call:Range(1,x)", checks.ErrorType))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Code")
+	errs = errs[:0]
+	errs = checks.Append(errs, c.Call("Code", "call:HasPrefix(ab)", &v.Code)...)
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Any")
+	if v.Any != nil {
+		c.Normalize(v.Any)
+	}
+	errs = errs[:0]
+	err = nil
+	if v.Any != nil {
+		err = checks.CallChecker(v.Any)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		errs = checks.Append(errs, checks.Enum("Any", v.Any))
+		errs = checks.Append(errs, c.Call("Any", "call:Valid", &v.Any)...)
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	if !c.Skipped() {
+		if v.Any != nil && !c.Nested(v.Any) {
+			return false
+		}
+	}
+	c.Leave()
+	c.EnterField("Env")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Expect("Env", v.Env, _checks_values_4))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Title")
+	errs = errs[:0]
+	err = nil
+	if v.Title != nil {
+		err = checks.CallChecker(v.Title)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		errs = checks.Append(errs, checks.Required("Title", v.Title == nil))
+		if v.Title == nil {
+			errs = append(errs, checks.NewError(checks.ErrValueUnexpected, "Title", "<nil>", checks.ErrorType))
+		} else {
+			errs = checks.Append(errs, checks.Match("Title", "re:^[a-z ]+$", _checks_re_5, v.Title))
+		}
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Aliases")
+	for i0 := range v.Aliases {
+		c.EnterIndex(i0)
+		errs = errs[:0]
+		errs = checks.Append(errs, checks.Match("Aliases", "re:^[A-Z]+$", _checks_re_6, v.Aliases[i0]))
+		if !c.Add(errs...) {
+			return false
+		}
+		c.Leave()
+	}
+	c.Leave()
+	c.EnterField("BadTrim")
+	errs = errs[:0]
+	errs = append(errs, checks.NewError(checks.ErrBadSyntax, "BadTrim", "trim", checks.ErrorType))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Ratio")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Expect("Ratio", v.Ratio, _checks_values_7))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Count")
+	errs = errs[:0]
+	err = nil
+	if v.Count != nil {
+		err = checks.CallChecker(v.Count)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		if v.Count == nil {
+			errs = append(errs, checks.NewError(checks.ErrValueUnexpected, "Count", "<nil>", checks.ErrorType))
+		} else {
+			errs = checks.Append(errs, checks.Expect("Count", *v.Count, _checks_values_8))
+		}
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Strict")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Expect("Strict", v.Strict, _checks_values_9))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Scheme")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.IExpect("Scheme", v.Scheme, _checks_values_10))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Proto")
+	errs = errs[:0]
+	err = nil
+	if v.Proto != nil {
+		err = checks.CallChecker(v.Proto)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		if v.Proto == nil {
+			errs = append(errs, checks.NewError(checks.ErrValueUnexpected, "Proto", "<nil>", checks.ErrorType))
+		} else {
+			errs = checks.Append(errs, checks.IExpect("Proto", *v.Proto, _checks_values_11))
+		}
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Color")
+	errs = errs[:0]
+	err = checks.CallChecker(v.Color)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		errs = checks.Append(errs, checks.Enum("Color", v.Color))
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Colors")
+	for i0 := range v.Colors {
+		c.EnterIndex(i0)
+		errs = errs[:0]
+		err = checks.CallChecker(v.Colors[i0])
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			errs = checks.Append(errs, checks.Enum("Colors", v.Colors[i0]))
+			errs = checks.Append(errs, checks.Required("Colors", v.Colors[i0] == 0))
+		}
+		if !c.Add(errs...) {
+			return false
+		}
+		c.Leave()
+	}
+	c.Leave()
+	c.EnterField("Shade")
+	errs = errs[:0]
+	err = checks.CallChecker(v.Shade)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		errs = checks.Append(errs, checks.Expect("Shade", v.Shade, _checks_values_12))
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Format")
+	errs = errs[:0]
+	err = nil
+	if v.Format != nil {
+		err = checks.CallChecker(v.Format)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		if v.TLS {
+			errs = checks.Append(errs, checks.Enum("Format", v.Format))
+		}
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Formats")
+	keys0 := make([]string, 0, len(v.Formats))
+	for k0 := range v.Formats {
+		keys0 = append(keys0, k0)
+	}
+	sort.Slice(keys0, func(i, j int) bool {
+		return keys0[i] < keys0[j]
+	})
+	for _, k0 := range keys0 {
+		e0 := v.Formats[k0]
+		c.EnterKey(k0)
+		errs = errs[:0]
+		err = checks.CallChecker(e0)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if !c.Add(errs...) {
+			return false
+		}
+		c.Leave()
+	}
+	c.Leave()
+	c.EnterField("Driver")
+	errs = errs[:0]
+	err = nil
+	if v.Driver != nil {
+		err = checks.CallChecker(v.Driver)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		if v.Driver == nil {
+			errs = append(errs, checks.NewError(checks.ErrValueUnexpected, "Driver", "<nil>", checks.ErrorType))
+		} else {
+			errs = checks.Append(errs, c.ExpectFrom("Driver", "expect:@AllowedDrivers", v, *v.Driver))
+		}
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Drivers")
+	for i0 := range v.Drivers {
+		c.EnterIndex(i0)
+		errs = errs[:0]
+		errs = checks.Append(errs, c.ExpectFrom("Drivers", "expect:@AllowedDrivers", v, v.Drivers[i0]))
+		if !c.Add(errs...) {
+			return false
+		}
+		c.Leave()
+	}
+	c.Leave()
+	c.EnterField("Plugin")
+	errs = errs[:0]
+	errs = checks.Append(errs, c.ExpectFrom("Plugin", "iexpect:@Plugins", v, v.Plugin))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("MissingValues")
+	errs = errs[:0]
+	errs = checks.Append(errs, c.ExpectFrom("MissingValues", "expect:@Missing", v, v.MissingValues))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("WrongValues")
+	errs = errs[:0]
+	errs = checks.Append(errs, c.ExpectFrom("WrongValues", "expect:@Range", v, v.WrongValues))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Listeners")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Unique("Listeners", "unique:Address", v.Listeners)...)
+	if !c.Add(errs...) {
+		return false
+	}
+	if !c.Skipped() {
+		for i0 := range v.Listeners {
+			c.EnterIndex(i0)
+			errs = errs[:0]
+			err = checks.CallChecker(v.Listeners[i0])
+			if err != nil {
+				errs = append(errs, err)
+			}
+			if !c.Add(errs...) {
+				return false
+			}
+			if !c.Skipped() {
+				if !_checks_Backend(c, &v.Listeners[i0]) {
+					return false
+				}
+			}
+			c.Leave()
+		}
+	}
+	c.Leave()
+	c.EnterField("Ports")
+	errs = errs[:0]
+	err = nil
+	if v.Ports != nil {
+		err = checks.CallChecker(v.Ports)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		errs = checks.Append(errs, checks.Unique("Ports", "unique", v.Ports)...)
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Hostnames")
+	keys1 := make([]string, 0, len(v.Hostnames))
+	for k0 := range v.Hostnames {
+		keys1 = append(keys1, k0)
+	}
+	sort.Slice(keys1, func(i, j int) bool {
+		return keys1[i] < keys1[j]
+	})
+	for _, k0 := range keys1 {
+		e0 := v.Hostnames[k0]
+		c.EnterKey(k0)
+		errs = errs[:0]
+		errs = checks.Append(errs, checks.Unique("Hostnames", "unique", e0)...)
+		if !c.Add(errs...) {
+			return false
+		}
+		c.Leave()
+	}
+	c.Leave()
+	c.EnterField("Upstreams")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Unique("Upstreams", "unique:Weight", v.Upstreams)...)
+	if !c.Add(errs...) {
+		return false
+	}
+	if !c.Skipped() {
+		for i0 := range v.Upstreams {
+			c.EnterIndex(i0)
+			errs = errs[:0]
+			err = nil
+			if v.Upstreams[i0] != nil {
+				err = checks.CallChecker(v.Upstreams[i0])
+			}
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				errs = checks.Append(errs, checks.Unique("Upstreams", "unique:Address", v.Upstreams[i0])...)
+			}
+			if !c.Add(errs...) {
+				return false
+			}
+			if !c.Skipped() {
+				if v.Upstreams[i0] != nil && !_checks_Backend(c, v.Upstreams[i0]) {
+					return false
+				}
+			}
+			c.Leave()
+		}
+	}
+	c.Leave()
+	c.EnterField("Endpoints")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Unique("Endpoints", "unique:Address", v.Endpoints)...)
+	if !c.Add(errs...) {
+		return false
+	}
+	if !c.Skipped() {
+		for i0 := range v.Endpoints {
+			c.EnterIndex(i0)
+			if v.Endpoints[i0] != nil {
+				c.Normalize(v.Endpoints[i0])
+			}
+			errs = errs[:0]
+			err = nil
+			if v.Endpoints[i0] != nil {
+				err = checks.CallChecker(v.Endpoints[i0])
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+			if !c.Add(errs...) {
+				return false
+			}
+			if !c.Skipped() {
+				if v.Endpoints[i0] != nil && !c.Nested(v.Endpoints[i0]) {
+					return false
+				}
+			}
+			c.Leave()
+		}
+	}
+	c.Leave()
+	c.EnterField("NoUnique")
+	errs = errs[:0]
+	errs = append(errs, checks.NewError(checks.ErrBadSyntax, "NoUnique", "unique", checks.ErrorType))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("BadUnique")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Unique("BadUnique", "unique:Missing", v.BadUnique)...)
+	if !c.Add(errs...) {
+		return false
+	}
+	if !c.Skipped() {
+		for i0 := range v.BadUnique {
+			c.EnterIndex(i0)
+			errs = errs[:0]
+			err = checks.CallChecker(v.BadUnique[i0])
+			if err != nil {
+				errs = append(errs, err)
+			}
+			if !c.Add(errs...) {
+				return false
+			}
+			if !c.Skipped() {
+				if !_checks_Backend(c, &v.BadUnique[i0]) {
+					return false
+				}
+			}
+			c.Leave()
+		}
+	}
+	c.Leave()
+	c.EnterField("ElemUnique")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Unique("ElemUnique", "unique:Address", v.ElemUnique)...)
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("OpenUnique")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Unique("OpenUnique", "unique:", v.OpenUnique)...)
+	if !c.Add(errs...) {
+		return false
+	}
+	if !c.Skipped() {
+		for i0 := range v.OpenUnique {
+			c.EnterIndex(i0)
+			errs = errs[:0]
+			err = checks.CallChecker(v.OpenUnique[i0])
+			if err != nil {
+				errs = append(errs, err)
+			}
+			if !c.Add(errs...) {
+				return false
+			}
+			if !c.Skipped() {
+				if !_checks_Backend(c, &v.OpenUnique[i0]) {
+					return false
+				}
+			}
+			c.Leave()
+		}
+	}
+	c.Leave()
+	c.EnterField("NoDigits")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.NotMatch("NoDigits", "!re:[0-9]", _checks_re_13, v.NoDigits))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Slug")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Match("Slug", "match:[a-z]+", _checks_re_14, v.Slug))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("User")
+	errs = errs[:0]
+	err = nil
+	if v.User != nil {
+		err = checks.CallChecker(v.User)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		if v.User != nil {
+			errs = checks.Append(errs, checks.NotMatch("User", "!match:admin|root", _checks_re_15, v.User))
+		}
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Host")
+	errs = errs[:0]
+	err = checks.CallChecker(v.Host)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		errs = checks.Append(errs, checks.Enum("Host", v.Host))
+		errs = checks.Append(errs, checks.Match("Host", "match:[0-9.]+", _checks_re_16, v.Host))
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	if !c.Skipped() {
+		if !c.Nested(&v.Host) {
+			return false
+		}
+	}
+	c.Leave()
+	c.EnterField("Raw")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.NotMatch("Raw", "!re:^ ", _checks_re_17, v.Raw))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("BadMatch")
+	errs = errs[:0]
+	errs = append(errs, checks.NewError(errors.New("error parsing regexp: missing closing ]: `[a-z`"), "BadMatch", "!match:[a-z", checks.ErrorType))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Service")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Required("Service", v.Service == ""))
+	errs = checks.Append(errs, checks.Match("Service", "match:@name", _checks_re_18, v.Service))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Services")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Unique("Services", "unique", v.Services)...)
+	if !c.Add(errs...) {
+		return false
+	}
+	if !c.Skipped() {
+		for i0 := range v.Services {
+			c.EnterIndex(i0)
+			errs = errs[:0]
+			errs = checks.Append(errs, checks.Required("Services", v.Services[i0] == ""))
+			errs = checks.Append(errs, checks.Match("Services", "match:@name", _checks_re_19, v.Services[i0]))
+			if !c.Add(errs...) {
+				return false
+			}
+			c.Leave()
+		}
+	}
+	c.Leave()
+	c.EnterField("Owner")
+	errs = errs[:0]
+	err = nil
+	if v.Owner != nil {
+		err = checks.CallChecker(v.Owner)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		if v.Owner != nil {
+			errs = checks.Append(errs, checks.NotMatch("Owner", "!re:@name", _checks_re_20, v.Owner))
+		}
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Version")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.MatchRule("Version", "match:@semver", v.Version))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Mention")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Match("Mention", "match:@@[a-z]+", _checks_re_21, v.Mention))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Nested")
+	errs = errs[:0]
+	err = checks.CallChecker(v.Nested)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	if !c.Skipped() {
+		if !_checks_Nested(c, &v.Nested) {
+			return false
+		}
+	}
+	c.Leave()
+	c.EnterField("NestedPtr")
+	errs = errs[:0]
+	err = nil
+	if v.NestedPtr != nil {
+		err = checks.CallChecker(v.NestedPtr)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	if !c.Skipped() {
+		if v.NestedPtr != nil && !_checks_Nested(c, v.NestedPtr) {
+			return false
+		}
+	}
+	c.Leave()
+	c.EnterField("Backends")
+	for i0 := range v.Backends {
+		c.EnterIndex(i0)
+		errs = errs[:0]
+		err = checks.CallChecker(v.Backends[i0])
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if !c.Add(errs...) {
+			return false
+		}
+		if !c.Skipped() {
+			if !_checks_Backend(c, &v.Backends[i0]) {
+				return false
+			}
+		}
+		c.Leave()
+	}
+	c.Leave()
+	c.EnterField("Named")
+	keys2 := make([]string, 0, len(v.Named))
+	for k0 := range v.Named {
+		keys2 = append(keys2, k0)
+	}
+	sort.Slice(keys2, func(i, j int) bool {
+		return keys2[i] < keys2[j]
+	})
+	for _, k0 := range keys2 {
+		e0 := v.Named[k0]
+		c.EnterKey(k0)
+		errs = errs[:0]
+		err = nil
+		if e0 != nil {
+			err = checks.CallChecker(e0)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if !c.Add(errs...) {
+			return false
+		}
+		if !c.Skipped() {
+			if e0 != nil && !_checks_Backend(c, e0) {
+				return false
+			}
+		}
+		c.Leave()
+	}
+	c.Leave()
+	c.EnterField("Plugins")
+	for i0 := range v.Plugins {
+		c.EnterIndex(i0)
+		if v.Plugins[i0] != nil {
+			c.Normalize(v.Plugins[i0])
+		}
+		errs = errs[:0]
+		err = nil
+		if v.Plugins[i0] != nil {
+			err = checks.CallChecker(v.Plugins[i0])
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if !c.Add(errs...) {
+			return false
+		}
+		if !c.Skipped() {
+			if v.Plugins[i0] != nil && !c.Nested(v.Plugins[i0]) {
+				return false
+			}
+		}
+		c.Leave()
+	}
+	c.Leave()
+	c.EnterField("Mode")
+	errs = errs[:0]
+	err = nil
+	if v.Mode != nil {
+		err = checks.CallChecker(v.Mode)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Cert")
+	errs = errs[:0]
+	if v.TLS {
+		errs = checks.Append(errs, checks.Required("Cert", v.Cert == ""))
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Key")
+	errs = errs[:0]
+	if !(v.TLS) {
+		if !(v.Cert == "") {
+			errs = checks.Append(errs, checks.Deprecated("Key", v.Key == ""))
+		}
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("CA")
+	errs = errs[:0]
+	if v.Mode != nil && checks.OneOf(*v.Mode, _checks_values_22) {
+		errs = checks.Append(errs, checks.Required("CA", v.CA == ""))
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Insecure")
+	errs = errs[:0]
+	if !(v.Mode != nil && checks.OneOf(*v.Mode, _checks_values_23)) {
+		errs = checks.Append(errs, checks.Expect("Insecure", v.Insecure, _checks_values_24))
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Invalid")
+	errs = errs[:0]
+	if v.Enabled {
+		errs = append(errs, checks.NewError(checks.ErrFieldNotFound, "Invalid", "if:Missing", checks.ErrorType))
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Zone")
+	errs = errs[:0]
+	if !(v.region == "") {
+		if !(checks.OneOf(v.region, _checks_values_25)) {
+			errs = checks.Append(errs, checks.Required("Zone", v.Zone == ""))
+		}
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("ID")
+	errs = errs[:0]
+	if c.InGroup(_checks_values_26) {
+		errs = checks.Append(errs, checks.Required("ID", v.ID == ""))
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Draft")
+	errs = errs[:0]
+	if c.InGroup(_checks_values_28) {
+		if v.TLS {
+			errs = checks.Append(errs, checks.Expect("Draft", v.Draft, _checks_values_27))
+		}
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("BadGroup")
+	errs = errs[:0]
+	if c.InGroup(_checks_values_29) {
+		errs = checks.Append(errs, checks.Required("BadGroup", v.BadGroup == ""))
+	} else {
+		errs = append(errs, checks.NewError(checks.ErrBadSyntax, "BadGroup", "group:", checks.ErrorType))
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Labels")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Required("Labels", len(v.Labels) == 0))
+	errs = checks.Append(errs, checks.MinLen("Labels", "minlen:2", 2, len(v.Labels)))
+	if !c.Add(errs...) {
+		return false
+	}
+	if !c.Skipped() {
+		for i0 := range v.Labels {
+			c.EnterIndex(i0)
+			errs = errs[:0]
+			if v.TLS {
+				errs = checks.Append(errs, checks.Match("Labels", "re:^[a-z]+$", _checks_re_30, v.Labels[i0]))
+			}
+			if !c.Add(errs...) {
+				return false
+			}
+			c.Leave()
+		}
+	}
+	c.Leave()
+	c.EnterField("Hosts")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.MinLen("Hosts", "minlen:1", 1, len(v.Hosts)))
+	if !c.Add(errs...) {
+		return false
+	}
+	if !c.Skipped() {
+		keys3 := make([]string, 0, len(v.Hosts))
+		for k0 := range v.Hosts {
+			keys3 = append(keys3, k0)
+		}
+		sort.Slice(keys3, func(i, j int) bool {
+			return keys3[i] < keys3[j]
+		})
+		for _, k0 := range keys3 {
+			e0 := v.Hosts[k0]
+			c.EnterKey(k0)
+			errs = errs[:0]
+			errs = checks.Append(errs, checks.Match("Hosts", "re:^[a-z]+$", _checks_re_31, k0))
+			if !c.Add(errs...) {
+				return false
+			}
+			errs = errs[:0]
+			errs = checks.Append(errs, checks.Required("Hosts", e0 == ""))
+			if !c.Add(errs...) {
+				return false
+			}
+			c.Leave()
+		}
+	}
+	c.Leave()
+	c.EnterField("Matrix")
+	for i0 := range v.Matrix {
+		c.EnterIndex(i0)
+		errs = errs[:0]
+		errs = checks.Append(errs, checks.MinLen("Matrix", "minlen:1", 1, len(v.Matrix[i0])))
+		if !c.Add(errs...) {
+			return false
+		}
+		if !c.Skipped() {
+			for i1 := range v.Matrix[i0] {
+				c.EnterIndex(i1)
+				errs = errs[:0]
+				errs = checks.Append(errs, checks.Expect("Matrix", v.Matrix[i0][i1], _checks_values_32))
+				if !c.Add(errs...) {
+					return false
+				}
+				c.Leave()
+			}
+		}
+		c.Leave()
+	}
+	c.Leave()
+	c.EnterField("Peers")
+	for i0 := range v.Peers {
+		c.EnterIndex(i0)
+		errs = errs[:0]
+		err = checks.CallChecker(v.Peers[i0])
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			errs = checks.Append(errs, checks.Required("Peers", checks.IsZero(v.Peers[i0])))
+		}
+		if !c.Add(errs...) {
+			return false
+		}
+		if !c.Skipped() {
+			if !_checks_Backend(c, &v.Peers[i0]) {
+				return false
+			}
+		}
+		c.Leave()
+	}
+	c.Leave()
+	c.EnterField("BadDive")
+	errs = errs[:0]
+	errs = append(errs, checks.NewError(checks.ErrBadSyntax, "BadDive", "dive", checks.ErrorType))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("BadKeys")
+	errs = errs[:0]
+	errs = append(errs, checks.NewError(checks.ErrBadSyntax, "BadKeys", "keys", checks.ErrorType))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("TrimKeys")
+	errs = errs[:0]
+	errs = append(errs, checks.NewError(checks.ErrBadSyntax, "TrimKeys", "lower", checks.ErrorType))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("BadLen")
+	errs = errs[:0]
+	errs = append(errs, checks.NewError(checks.ErrBadSyntax, "BadLen", "minlen:1", checks.ErrorType))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Sub")
+	errs = errs[:0]
+	err = checks.CallChecker(v.Sub)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		errs = checks.Append(errs, checks.Enum("Sub", v.Sub))
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	if !c.Skipped() {
+		if !c.Nested(&v.Sub) {
+			return false
+		}
+	}
+	c.Leave()
+	c.EnterField("SubPtr")
+	errs = errs[:0]
+	err = nil
+	if v.SubPtr != nil {
+		err = checks.CallChecker(v.SubPtr)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		errs = checks.Append(errs, checks.Enum("SubPtr", v.SubPtr))
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	if !c.Skipped() {
+		if v.SubPtr != nil && !c.Nested(v.SubPtr) {
+			return false
+		}
+	}
+	c.Leave()
+	c.EnterField("Subs")
+	for i0 := range v.Subs {
+		c.EnterIndex(i0)
+		errs = errs[:0]
+		err = checks.CallChecker(v.Subs[i0])
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if !c.Add(errs...) {
+			return false
+		}
+		if !c.Skipped() {
+			if !c.Nested(&v.Subs[i0]) {
+				return false
+			}
+		}
+		c.Leave()
+	}
+	c.Leave()
+	c.EnterField("SubMap")
+	keys5 := make([]string, 0, len(v.SubMap))
+	for k0 := range v.SubMap {
+		keys5 = append(keys5, k0)
+	}
+	sort.Slice(keys5, func(i, j int) bool {
+		return keys5[i] < keys5[j]
+	})
+	for _, k0 := range keys5 {
+		e0 := v.SubMap[k0]
+		c.EnterKey(k0)
+		errs = errs[:0]
+		err = checks.CallChecker(e0)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if !c.Add(errs...) {
+			return false
+		}
+		if !c.Skipped() {
+			if !c.Nested(&e0) {
+				return false
+			}
+		}
+		c.Leave()
+	}
+	c.Leave()
+	c.EnterField("Meta")
+	errs = errs[:0]
+	err = checks.CallChecker(v.Meta)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	if !c.Skipped() {
+		if !_checks_Meta(c, &v.Meta) {
+			return false
+		}
+	}
+	c.EnterField("audit")
+	errs = errs[:0]
+	err = nil
+	if v.audit != nil {
+		err = checks.CallChecker(v.audit)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	if !c.Skipped() {
+		if v.audit != nil && !_checks_audit(c, v.audit) {
+			return false
+		}
+	}
+	c.EnterField("Base")
+	errs = errs[:0]
+	err = nil
+	if v.Base != nil {
+		err = checks.CallChecker(v.Base)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		errs = checks.Append(errs, checks.Enum("Base", v.Base))
+	}
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	if !c.Skipped() {
+		if v.Base != nil && !c.Embedded("Base", v.Base) {
+			return false
+		}
+	}
+	switch c.Unexported() {
+	case checks.UnexportedCheck:
+		c.EnterField("secret")
+		errs = errs[:0]
+		errs = checks.Append(errs, checks.Required("secret", v.secret == ""))
+		if !c.Add(errs...) {
+			return false
+		}
+		c.Leave()
+	case checks.UnexportedError:
+		c.EnterField("secret")
+		if !c.Add(checks.NewError(checks.ErrUnexported, "secret", nil, checks.ErrorType)) {
+			return false
+		}
+		c.Leave()
+	}
+	switch c.Unexported() {
+	case checks.UnexportedCheck:
+		c.EnterField("inner")
+		errs = errs[:0]
+		err = checks.CallChecker(v.inner)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if !c.Add(errs...) {
+			return false
+		}
+		if !c.Skipped() {
+			if !_checks_Nested(c, &v.inner) {
+				return false
+			}
+		}
+		c.Leave()
+	}
+	switch c.Unexported() {
+	case checks.UnexportedCheck:
+		c.EnterField("peers")
+		for i0 := range v.peers {
+			c.EnterIndex(i0)
+			errs = errs[:0]
+			err = checks.CallChecker(v.peers[i0])
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				errs = checks.Append(errs, checks.Required("peers", checks.IsZero(v.peers[i0])))
+			}
+			if !c.Add(errs...) {
+				return false
+			}
+			if !c.Skipped() {
+				if !_checks_Backend(c, &v.peers[i0]) {
+					return false
+				}
+			}
+			c.Leave()
+		}
+		c.Leave()
+	case checks.UnexportedError:
+		c.EnterField("peers")
+		if !c.Add(checks.NewError(checks.ErrUnexported, "peers", nil, checks.ErrorType)) {
+			return false
+		}
+		c.Leave()
+	}
+	return true
+}
+
+func _checks_Nested(c *checks.Collector, v *Nested) bool {
+	var errs []error
+	c.EnterField("Name")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Required("Name", v.Name == ""))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Tags")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Required("Tags", len(v.Tags) == 0))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	return true
+}
+
+func _checks_Backend(c *checks.Collector, v *Backend) bool {
+	var errs []error
+	c.EnterField("Address")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Required("Address", v.Address == ""))
+	errs = checks.Append(errs, checks.Match("Address", "re:^[a-z]+:[0-9]+$", _checks_re_33, v.Address))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	c.EnterField("Weight")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Expect("Weight", v.Weight, _checks_values_34))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	return true
+}
+
+func _checks_Meta(c *checks.Collector, v *Meta) bool {
+	var errs []error
+	c.EnterField("Owner")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Required("Owner", v.Owner == ""))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	switch c.Unexported() {
+	case checks.UnexportedCheck:
+		c.EnterField("note")
+		errs = errs[:0]
+		errs = checks.Append(errs, checks.Required("note", v.note == ""))
+		if !c.Add(errs...) {
+			return false
+		}
+		c.Leave()
+	case checks.UnexportedError:
+		c.EnterField("note")
+		if !c.Add(checks.NewError(checks.ErrUnexported, "note", nil, checks.ErrorType)) {
+			return false
+		}
+		c.Leave()
+	}
+	return true
+}
+
+func _checks_audit(c *checks.Collector, v *audit) bool {
+	var errs []error
+	c.EnterField("Author")
+	errs = errs[:0]
+	errs = checks.Append(errs, checks.Required("Author", v.Author == ""))
+	errs = checks.Append(errs, checks.Expect("Author", v.Author, _checks_values_35))
+	if !c.Add(errs...) {
+		return false
+	}
+	c.Leave()
+	return true
+}
+
+func init() {
+	checks.Register((*Config)(nil), func(c *checks.Collector, v interface{}) bool {
+		if !c.Add(checks.Append(nil, checks.CallChecker(v))...) {
+			return false
+		}
+		return c.Skipped() || _checks_Config(c, v.(*Config))
+	})
+	checks.Register(Config{}, func(c *checks.Collector, v interface{}) bool {
+		if !c.Add(checks.Append(nil, checks.CallChecker(v))...) {
+			return false
+		}
+		if c.Skipped() {
+			return true
+		}
+		value := v.(Config)
+		return _checks_Config(c, &value)
+	})
+	checks.Register((*Nested)(nil), func(c *checks.Collector, v interface{}) bool {
+		if !c.Add(checks.Append(nil, checks.CallChecker(v))...) {
+			return false
+		}
+		return c.Skipped() || _checks_Nested(c, v.(*Nested))
+	})
+	checks.Register(Nested{}, func(c *checks.Collector, v interface{}) bool {
+		if !c.Add(checks.Append(nil, checks.CallChecker(v))...) {
+			return false
+		}
+		if c.Skipped() {
+			return true
+		}
+		value := v.(Nested)
+		return _checks_Nested(c, &value)
+	})
+}
@@ -0,0 +1,235 @@
+package gentest
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/arteev/go-checks"
+	"github.com/arteev/go-checks/internal/gentest/sub"
+	"github.com/stretchr/testify/assert"
+)
+
+func errorStrings(errs []error) []string {
+	if errs == nil {
+		return nil
+	}
+	result := make([]string, 0, len(errs))
+	for _, e := range errs {
+		result = append(result, e.Error())
+	}
+	return result
+}
+
+func init() {
+	checks.RegisterPattern("semver", `[0-9]+\.[0-9]+\.[0-9]+`)
+}
+
+func TestGeneratedEqualsReflective(t *testing.T) {
+	port := 8080
+	validPort := 443
+	digits := "123"
+	tls, mtls := "tls", "mtls"
+	one, info := 1, Level("INFO")
+	json, xml := Format("json"), Format("xml")
+	sqlite, mysql, root := "sqlite", "mysql", "root"
+	values := []interface{}{
+		(*Config)(nil),
+		&Config{},
+		Config{Enabled: true},
+		&Config{Enabled: true},
+		&Config{
+			Enabled:      true,
+			Listen:       ":8080",
+			LogLevel:     "warn",
+			Timeout:      10,
+			Port:         &port,
+			ValueForFunc: "valid",
+			ValueRegexp:  "123",
+			PtrRegexp:    &digits,
+			Nested:       Nested{Name: "nested", Fail: true},
+			NestedPtr:    &Nested{Tags: []string{"a"}},
+			Backends: []Backend{
+				{Address: "localhost:80", Weight: 1},
+				{Address: "bad", Weight: 20},
+			},
+			Named: map[string]*Backend{
+				"one": {Address: "a:1", Weight: 4},
+			},
+			Plugins: []interface{}{nil, &Backend{Weight: 11}, Nested{Fail: true}},
+		},
+		&Config{
+			Enabled:      true,
+			Listen:       ":8080",
+			LogLevel:     "debug",
+			Port:         &validPort,
+			ValueForFunc: "valid",
+			ValueRegexp:  "abc",
+		},
+		&Config{
+			Enabled:   true,
+			Nested:    Nested{Name: "skip"},
+			NestedPtr: &Nested{Name: "skip", Fail: false},
+			Backends:  []Backend{{Weight: 30}},
+		},
+		&Config{Enabled: true, TLS: true, Key: "key", Insecure: 1},
+		&Config{Enabled: true, Cert: "cert", Key: "key", Mode: &mtls, Insecure: 1},
+		&Config{Enabled: true, Key: "key", Mode: &tls, Insecure: 1},
+		&Config{Enabled: true, Key: "key", Insecure: 1, region: "eu"},
+		&Config{Enabled: true, Key: "key", Insecure: 1, region: "local"},
+		&Config{Enabled: true, TLS: true, Key: "key", ID: "1", Draft: "no"},
+		&Config{
+			Enabled:  true,
+			TLS:      true,
+			Labels:   []string{"a", "B"},
+			Hosts:    map[string]string{"Local": "", "b": "", "a": "a", "C": "c"},
+			TrimKeys: map[string]string{" A ": " a "},
+			Named: map[string]*Backend{
+				"z": {Address: "bad"},
+				"b": nil,
+				"a": {Address: "a:1", Weight: 11},
+				"m": {Weight: 4},
+			},
+			Matrix: [][]int{{0, 2}, {}},
+			Peers:  []Backend{{}, {Address: "a:1", Weight: 2}},
+		},
+		&Config{
+			Enabled: true,
+			Meta:    Meta{note: "note"},
+			audit:   &audit{Author: "user"},
+			inner:   Nested{Tags: []string{"a"}},
+			peers:   []Backend{{}, {Weight: 12}},
+		},
+		Config{Enabled: true, Meta: Meta{Owner: "owner"}, secret: "secret"},
+		&Config{
+			Enabled:   true,
+			Region:    "mars",
+			Comment:   "too long",
+			Retries:   []int{1, 5, 7},
+			Verbosity: "trace",
+			Retry:     5,
+			Code:      "xy",
+			Any:       Level("trace"),
+		},
+		&Config{Enabled: true, Retry: 2, Code: "abc", Any: "any"},
+		&Config{Enabled: true, Ratio: 0.1, Count: &one, Scheme: "HTTPS", Proto: &info},
+		&Config{Enabled: true, Ratio: 0.2, Count: &port, Strict: true, Scheme: "ftp"},
+		&Config{Enabled: true, Color: 2, Colors: []Color{1, 0, 5}, Shade: 7, Format: &json, Formats: map[string]Format{"a": "json"}},
+		&Config{Enabled: true, TLS: true, Color: 4, Colors: []Color{3}, Shade: 2, Format: &xml, Formats: map[string]Format{"a": "xml"}},
+		&Config{Enabled: true, Driver: &sqlite, Drivers: []string{"postgres", "oracle"}, Plugin: "Auth"},
+		&Config{Enabled: true, Driver: &mysql, Drivers: []string{"sqlite"}, Plugin: "log", MissingValues: "x"},
+		&Config{
+			Enabled:   true,
+			Listeners: []Backend{{Address: "a:1"}, {Address: "b:1"}, {Address: "a:1"}, {Address: "a:1"}},
+			Ports:     &[3]int{80, 443, 80},
+			Hostnames: map[string][]string{"b": {"x", "y", "x"}, "a": {"z", "z"}},
+			Upstreams: []*Backend{{Weight: 1}, nil, {Weight: 2}, nil, {Weight: 1}},
+			Endpoints: []interface{}{Backend{Address: "a:1"}, 1, &Backend{Address: "a:1"}, nil, "x"},
+		},
+		&Config{Enabled: true, NoDigits: "a1", Slug: "abc", User: &root, Host: net.IPv4(127, 0, 0, 1), Raw: []byte(" x")},
+		&Config{Enabled: true, NoDigits: "ab", Slug: "abc1", User: &sqlite, Host: net.IPv6loopback, Raw: []byte("x")},
+		&Config{Enabled: true, Service: " api ", Services: []string{"a", "B", "a"}, Owner: &root, Version: "1.2.3", Mention: "@api"},
+		&Config{Enabled: true, Service: "1api", Services: []string{"a-b"}, Owner: &digits, Version: "v1", Mention: "api"},
+		&Config{
+			Enabled: true,
+			Sub:     sub.Sub{Level: "warn"},
+			SubPtr:  &sub.Sub{Name: "a", Level: " info "},
+			Subs:    []sub.Sub{{Name: "a", Level: "info"}, {}},
+			SubMap:  map[string]sub.Sub{"b": {}, "a": {Name: "a", Level: "debug"}},
+			Base:    &sub.Base{},
+		},
+		&Nested{},
+		Nested{Name: "skip"},
+		Nested{Name: "name", Tags: []string{"tag"}},
+	}
+	modes := []checks.Mode{checks.ModeFirst, checks.ModeAll}
+	types := []checks.Type{checks.ErrorType, checks.WarningType, checks.ErrorAll}
+
+	for i, v := range values {
+		for _, m := range modes {
+			for _, typ := range types {
+				want := checks.New(m, typ).Check(v)
+				got := checks.New(m, typ, checks.WithGenerated()).Check(v)
+				assert.Equal(t, errorStrings(want), errorStrings(got),
+					fmt.Sprintf("value %d, mode %d, type %d", i, m, typ))
+
+				want = checks.New(m, typ, checks.WithSkipAll()).Check(v)
+				got = checks.New(m, typ, checks.WithSkipAll(), checks.WithGenerated()).Check(v)
+				assert.Equal(t, errorStrings(want), errorStrings(got),
+					fmt.Sprintf("skip all, value %d, mode %d, type %d", i, m, typ))
+
+				want = checks.New(m, typ, checks.WithBail(), checks.WithLimit(3)).Check(v)
+				got = checks.New(m, typ, checks.WithBail(), checks.WithLimit(3), checks.WithGenerated()).Check(v)
+				assert.Equal(t, errorStrings(want), errorStrings(got),
+					fmt.Sprintf("bail, value %d, mode %d, type %d", i, m, typ))
+
+				for _, p := range []checks.Unexported{checks.UnexportedCheck, checks.UnexportedError} {
+					want = checks.New(m, typ, checks.WithUnexported(p)).Check(v)
+					got = checks.New(m, typ, checks.WithUnexported(p), checks.WithGenerated()).Check(v)
+					assert.Equal(t, errorStrings(want), errorStrings(got),
+						fmt.Sprintf("unexported %d, value %d, mode %d, type %d", p, i, m, typ))
+				}
+
+				want = checks.New(m, typ, checks.WithGroups("create", "update")).Check(v)
+				got = checks.New(m, typ, checks.WithGroups("create", "update"), checks.WithGenerated()).Check(v)
+				assert.Equal(t, errorStrings(want), errorStrings(got),
+					fmt.Sprintf("groups, value %d, mode %d, type %d", i, m, typ))
+			}
+		}
+	}
+}
+
+func TestGeneratedNormalized(t *testing.T) {
+	values := func() []interface{} {
+		title := "  a   title "
+		return []interface{}{
+			&Config{
+				Enabled: true,
+				Env:     " Dev ",
+				Title:   &title,
+				Aliases: []string{" ab ", "c d"},
+				Nested:  Nested{Name: "  ", Tags: []string{"a"}},
+				Named:   map[string]*Backend{"one": {Address: " a:1 "}},
+				Plugins: []interface{}{&Nested{Name: " skip "}, Nested{Name: " "}},
+			},
+			Config{Enabled: true, Env: "PROD ", Title: &title, NestedPtr: &Nested{Name: " x "}},
+		}
+	}
+	for _, opts := range [][]checks.Option{nil, {checks.WithNormalizedCopy()}} {
+		want, got := values(), values()
+		for i := range want {
+			gen := checks.New(checks.ModeAll, checks.ErrorAll, append(opts, checks.WithGenerated())...).Check(got[i])
+			refl := checks.New(checks.ModeAll, checks.ErrorAll, opts...).Check(want[i])
+			assert.Equal(t, errorStrings(refl), errorStrings(gen), fmt.Sprintf("value %d", i))
+			assert.Equal(t, want[i], got[i], fmt.Sprintf("value %d", i))
+		}
+	}
+}
+
+func BenchmarkReflective(b *testing.B) {
+	benchmarkChecker(b, checks.New(checks.ModeAll, checks.ErrorAll))
+}
+
+func BenchmarkGenerated(b *testing.B) {
+	benchmarkChecker(b, checks.New(checks.ModeAll, checks.ErrorAll, checks.WithGenerated()))
+}
+
+func benchmarkChecker(b *testing.B, c *checks.SimpeChecker) {
+	port := 443
+	v := &Config{
+		Enabled:      true,
+		Listen:       ":8080",
+		LogLevel:     "debug",
+		Port:         &port,
+		ValueForFunc: "valid",
+		ValueRegexp:  "abc",
+		Nested:       Nested{Name: "nested", Tags: []string{"a"}},
+		Backends: []Backend{
+			{Address: "localhost:80", Weight: 1},
+			{Address: "localhost:81", Weight: 2},
+		},
+	}
+	for i := 0; i < b.N; i++ {
+		c.Check(v)
+	}
+}
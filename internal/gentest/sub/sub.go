@@ -0,0 +1,15 @@
+//Package sub contains types declared outside the package of generated checks
+package sub
+
+type (
+	//Sub is the nested structure
+	Sub struct {
+		Name  string `check:"required"`
+		Level string `check:"trim,expect:info;debug"`
+	}
+
+	//Base is the embedded structure
+	Base struct {
+		Region string `check:"required"`
+	}
+)
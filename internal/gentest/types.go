@@ -0,0 +1,283 @@
+//Package gentest contains types for comparing generated and reflective checks
+package gentest
+
+//go:generate go run ../../cmd/checksgen -type Config,Nested
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/arteev/go-checks"
+	"github.com/arteev/go-checks/internal/gentest/sub"
+)
+
+func init() {
+	checks.RegisterFunc("HasPrefix", func(field checks.Field, s string, prefix string) error {
+		if strings.HasPrefix(s, prefix) {
+			return nil
+		}
+		return fmt.Errorf("%s: no prefix %q", field.Path, prefix)
+	})
+	checks.RegisterValues("Plugins", func() []string {
+		return []string{"auth", "cache"}
+	})
+	checks.RegisterAlias("name", "trim,required,match:@name")
+	checks.RegisterAlias("names", "unique,dive,name")
+	checks.RegisterPattern("name", "[a-z][a-z0-9-]*")
+}
+
+type (
+	//Level of logging
+	Level string
+
+	//Color is the enumeration listing its values
+	Color int
+
+	//Format is the enumeration validating its values
+	Format string
+
+	//Config is the checked structure
+	Config struct {
+		Enabled  bool
+		Listen   string `check:"required"`
+		LogLevel Level  `check:"required,expect:info;debug;error;"`
+		Timeout  int    `check:"deprecated"`
+		Port     *int   `check:"required,expect:80;443"`
+
+		ValueForFunc string      `check:"call:ValueCheck"`
+		ValueRegexp  string      `check:"re:[a-z]+"`
+		PtrRegexp    *string     `check:"re:^[0-9]+$"`
+		BadRegexp    string      `check:"re:[a-z"`
+		BadSyntax    string      `check:"expect:"`
+		NotFound     string      `check:"call:NotFound"`
+		WrongResult  string      `check:"call:Wrong"`
+		Region       string      `check:"call:RegionCheck"`
+		Comment      string      `check:"call:Short"`
+		Retries      []int       `check:"dive,call:RetryCheck"`
+		Verbosity    Level       `check:"call:Valid"`
+		WrongArgs    string      `check:"call:TwoNames"`
+		Retry        int         `check:"call:Range(1,3)"`
+		BadArg       int         `check:"call:Range(1,x)"`
+		Code         string      `check:"call:HasPrefix(ab)"`
+		Any          interface{} `check:"call:Valid"`
+
+		Env     string   `check:"trim,lower,expect:dev;prod"`
+		Title   *string  `check:"collapse,required,re:^[a-z ]+$"`
+		Aliases []string `check:"dive,trim,upper,re:^[A-Z]+$"`
+		BadTrim int      `check:"trim"`
+
+		Ratio  float32 `check:"expect:0.1;1.0"`
+		Count  *int    `check:"expect:1.0;2"`
+		Strict bool    `check:"expect:FALSE"`
+		Scheme string  `check:"iexpect:HTTP;https"`
+		Proto  *Level  `check:"iexpect:Info"`
+
+		Color   Color
+		Colors  []Color `check:"dive,required"`
+		Shade   Color   `check:"expect:1;7"`
+		Format  *Format `check:"if:TLS"`
+		Formats map[string]Format
+
+		Driver        *string  `check:"expect:@AllowedDrivers"`
+		Drivers       []string `check:"dive,expect:@AllowedDrivers"`
+		Plugin        string   `check:"iexpect:@Plugins"`
+		MissingValues string   `check:"expect:@Missing"`
+		WrongValues   string   `check:"expect:@Range"`
+
+		Listeners  []Backend           `check:"unique:Address"`
+		Ports      *[3]int             `check:"unique"`
+		Hostnames  map[string][]string `check:"dive,unique"`
+		Upstreams  []*Backend          `check:"unique:Weight,dive,unique:Address"`
+		Endpoints  []interface{}       `check:"unique:Address"`
+		NoUnique   string              `check:"unique"`
+		BadUnique  []Backend           `check:"unique:Missing"`
+		ElemUnique []int               `check:"unique:Address"`
+		OpenUnique []Backend           `check:"unique:"`
+
+		NoDigits string  `check:"!re:[0-9]"`
+		Slug     string  `check:"match:[a-z]+"`
+		User     *string `check:"!match:admin|root"`
+		Host     net.IP  `check:"match:[0-9.]+"`
+		Raw      []byte  `check:"!re:^ "`
+		BadMatch string  `check:"!match:[a-z"`
+
+		Service  string   `check:"name"`
+		Services []string `check:"names"`
+		Owner    *string  `check:"!re:@name"`
+		Version  string   `check:"match:@semver"`
+		Mention  string   `check:"match:@@[a-z]+"`
+
+		Nested    Nested
+		NestedPtr *Nested
+		Backends  []Backend
+		Named     map[string]*Backend
+		Plugins   []interface{}
+
+		TLS      bool
+		Mode     *string
+		Cert     string `check:"required,if:TLS"`
+		Key      string `check:"if:!TLS,deprecated,if:Cert"`
+		CA       string `check:"if:Mode=mtls,required"`
+		Insecure int    `check:"if:Mode!=tls;mtls,expect:0"`
+		Invalid  string `check:"if:Enabled,required,if:Missing"`
+		region   string
+		Zone     string `check:"required,if:region,if:region!=local"`
+
+		ID       string `check:"group:update,required"`
+		Draft    string `check:"group:create;update,if:TLS,expect:yes,group:dry"`
+		BadGroup string `check:"group:update,group:,required"`
+
+		Labels   []string          `check:"required,minlen:2,dive,re:^[a-z]+$,if:TLS"`
+		Hosts    map[string]string `check:"minlen:1,dive,keys,re:^[a-z]+$,endkeys,required"`
+		Matrix   [][]int           `check:"dive,minlen:1,dive,expect:0;1"`
+		Peers    []Backend         `check:"dive,required"`
+		BadDive  string            `check:"dive,required"`
+		BadKeys  []string          `check:"dive,keys,required,endkeys"`
+		TrimKeys map[string]string `check:"dive,keys,lower,endkeys,trim"`
+		BadLen   int               `check:"minlen:1"`
+
+		Sub    sub.Sub
+		SubPtr *sub.Sub
+		Subs   []sub.Sub
+		SubMap map[string]sub.Sub
+
+		Meta
+		*audit
+		*sub.Base
+		secret string `check:"required"`
+		inner  Nested
+		peers  []Backend `check:"dive,required"`
+	}
+
+	//Meta is the embedded structure
+	Meta struct {
+		Owner string `check:"required"`
+		note  string `check:"required"`
+	}
+
+	audit struct {
+		Author string `check:"required,expect:admin"`
+	}
+
+	//Nested is the nested structure
+	Nested struct {
+		Name string   `check:"required"`
+		Tags []string `check:"required"`
+		Fail bool
+	}
+
+	//Backend implements checks.Checker
+	Backend struct {
+		Address string `check:"required,re:^[a-z]+:[0-9]+$"`
+		Weight  uint   `check:"expect:1;2;3"`
+	}
+)
+
+//Check implements checks.Checker
+func (c Config) Check() error {
+	if !c.Enabled {
+		return checks.ErrSkip
+	}
+	return nil
+}
+
+//ValueCheck checks ValueForFunc
+func (c Config) ValueCheck(name string, s string) error {
+	if s == "valid" {
+		return nil
+	}
+	return fmt.Errorf("not valid value: %s %q", name, s)
+}
+
+//Wrong has the wrong signature for the call check
+func (c Config) Wrong(name string, s string) bool {
+	return false
+}
+
+//RegionCheck checks Region
+func (c Config) RegionCheck(ctx context.Context, field checks.Field, s string) []error {
+	if s == "" || ctx == nil {
+		return nil
+	}
+	return []error{fmt.Errorf("%s: unknown region %q", field.Path, s), nil}
+}
+
+//Short checks Comment
+func (c Config) Short(s string) error {
+	if len(s) > 5 {
+		return errors.New("comment is too long")
+	}
+	return nil
+}
+
+//RetryCheck checks elements of Retries
+func (c *Config) RetryCheck(field checks.Field, retry int) error {
+	if retry > 3 {
+		return fmt.Errorf("%s: too many retries", field.Path)
+	}
+	return nil
+}
+
+//TwoNames has the wrong signature for the call check
+func (c Config) TwoNames(name string, field checks.Field, s string) error {
+	return nil
+}
+
+//Range checks the value is between min and max
+func (c Config) Range(name string, v int, min, max int) error {
+	if v < min || v > max {
+		return fmt.Errorf("%s: %d is out of range [%d, %d]", name, v, min, max)
+	}
+	return nil
+}
+
+//Valid checks the level
+func (l Level) Valid(name string) error {
+	switch l {
+	case "", "info", "debug", "error":
+		return nil
+	}
+	return fmt.Errorf("%s: unknown level %q", name, string(l))
+}
+
+//Drivers returns the allowed drivers
+func (Config) AllowedDrivers() []string {
+	return []string{"postgres", "sqlite"}
+}
+
+//Values returns the values of the color
+func (Color) Values() []Color {
+	return []Color{1, 2, 3}
+}
+
+//Valid implements checks.Enumer
+func (f Format) Valid() bool {
+	return f == "json" || f == "yaml"
+}
+
+//Normalize implements checks.Normalizer
+func (n *Nested) Normalize() {
+	n.Name = strings.TrimSpace(n.Name)
+}
+
+//Check implements checks.Checker
+func (n Nested) Check() error {
+	if n.Fail {
+		return errors.New("nested check")
+	}
+	if n.Name == "skip" {
+		return checks.ErrSkip
+	}
+	return nil
+}
+
+//Check implements checks.Checker
+func (b *Backend) Check() error {
+	if b.Weight > 10 {
+		return errors.New("weight too large")
+	}
+	return nil
+}
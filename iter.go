@@ -2,6 +2,7 @@ package checks
 
 import (
 	"reflect"
+	"strings"
 )
 
 type (
@@ -18,18 +19,30 @@ type (
 		Value() reflect.Value
 		Parent() Value
 		Struct() *reflect.StructField
+		//Key returns the key of the map element or of the map key value.
+		//Returns invalid reflect.Value for other values
+		Key() reflect.Value
+		//Path returns the path of the value from the checked value,
+		//e.g. Backends[1].Address or Named[one].Address
+		Path() string
 	}
 
 	iterator struct {
-		idx   int
-		nodes []*node
+		idx        int
+		nodes      []*node
+		unexported Unexported
 	}
 
 	node struct {
-		ptr      bool
-		parent   *node
-		value    reflect.Value
-		strField *reflect.StructField
+		end        int
+		ptr        bool
+		parent     *node
+		owner      *node
+		index      int
+		key        reflect.Value
+		unexported bool
+		value      reflect.Value
+		strField   *reflect.StructField
 	}
 )
 
@@ -40,8 +53,21 @@ var iterateable = map[reflect.Kind]struct{}{
 	reflect.Map:    struct{}{},
 }
 
+//elem returns the value held by the interface
+func elem(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+//unwrap returns the value held by the interface or pointed to by the pointer
+func unwrap(v reflect.Value) reflect.Value {
+	return reflect.Indirect(elem(v))
+}
+
 func canIterate(v reflect.Value) bool {
-	v = reflect.Indirect(v)
+	v = unwrap(v)
 	_, ok := iterateable[v.Kind()]
 	return ok
 }
@@ -70,6 +96,40 @@ func (n node) Parent() Value {
 	return n.parent
 }
 
+func (n node) Key() reflect.Value { return n.key }
+
+func (n *node) Path() string {
+	var b strings.Builder
+	n.writePath(&b)
+	return b.String()
+}
+
+//writePrefix writes the path of the parent of the nested value.
+//Names of embedded structs are omitted as fields of them are promoted
+func (n *node) writePrefix(b *strings.Builder) {
+	if n.strField != nil && n.parent != nil && promoted(*n.strField) &&
+		unwrap(n.parent.value).Kind() == reflect.Struct {
+		n.parent.writePrefix(b)
+		return
+	}
+	n.writePath(b)
+}
+
+func (n *node) writePath(b *strings.Builder) {
+	if n.parent == nil {
+		return
+	}
+	n.parent.writePrefix(b)
+	switch unwrap(n.parent.value).Kind() {
+	case reflect.Struct:
+		writeField(b, n.strField.Name)
+	case reflect.Slice, reflect.Array:
+		writeIndex(b, n.index)
+	case reflect.Map:
+		writeKey(b, n.key)
+	}
+}
+
 func (i *iterator) HasNext() bool {
 	if i == nil {
 		return false
@@ -78,59 +138,114 @@ func (i *iterator) HasNext() bool {
 }
 
 func (i *iterator) Next() Value {
+	return i.next()
+}
+
+func (i *iterator) next() *node {
 	result := i.nodes[i.idx]
 	i.idx++
 	return result
 }
 
-func (i *iterator) initValue(v reflect.Value, sf *reflect.StructField, parent *node) *node {
-	ptr := false
-	value := v
-	if v.Kind() == reflect.Ptr {
-		value = reflect.Indirect(v)
-		ptr = true
+//skipChildren skips nested values of the value returned by the last call of Next
+func (i *iterator) skipChildren() {
+	if i == nil || i.idx == 0 {
+		return
 	}
-	item := &node{
-		ptr:      ptr,
+	i.idx = i.nodes[i.idx-1].end
+}
+
+//initValue adds the node of the value and nodes of its nested values.
+//The owner is the node of the struct containing the field of the value
+func (i *iterator) initValue(v reflect.Value, sf *reflect.StructField, parent *node, owner *node) *node {
+	return i.initNode(&node{
 		value:    v,
 		strField: sf,
 		parent:   parent,
-	}
+		owner:    owner,
+	})
+}
+
+//initNode adds the node and nodes of its nested values
+func (i *iterator) initNode(item *node) *node {
+	item.ptr = item.value.Kind() == reflect.Ptr
+	normalizeHeld(item.value, i.unexported)
 	i.nodes = append(i.nodes, item)
 
-	if canIterate(v) {
-		i.initInterateable(value, nil, item)
+	if canIterate(item.value) {
+		keyField, elemField := diveFields(item.strField)
+		i.initInterateable(unwrap(item.value), keyField, elemField, item)
 	}
+	item.end = len(i.nodes)
 	return item
 }
 
-func (i *iterator) initInterateable(v reflect.Value, sf *reflect.StructField, parent *node) {
+//initInterateable adds nodes of the nested values. Map keys and elements are
+//described by keyField and elemField of the dive rule
+func (i *iterator) initInterateable(v reflect.Value, keyField, elemField *reflect.StructField, parent *node) {
 	switch v.Kind() {
 	case reflect.Struct:
+		cType := v.Type()
 		for k := 0; k < v.NumField(); k++ {
-			cType := v.Type()
 			strFieldCur := cType.Field(k)
-			i.initValue(v.Field(k), &strFieldCur, parent)
+			if !exported(strFieldCur) && i.unexported != UnexportedCheck {
+				if _, ok := strFieldCur.Tag.Lookup("check"); ok && i.unexported == UnexportedError {
+					i.nodes = append(i.nodes, &node{
+						value:      v.Field(k),
+						strField:   &strFieldCur,
+						parent:     parent,
+						owner:      parent,
+						unexported: true,
+						end:        len(i.nodes) + 1,
+					})
+				}
+				continue
+			}
+			if !v.Field(k).CanInterface() {
+				v = addressable(v)
+			}
+			i.initValue(accessible(v, k), &strFieldCur, parent, parent)
 		}
 	case reflect.Slice, reflect.Array:
 		for k := 0; k < v.Len(); k++ {
-			value := v.Index(k)
-			i.initValue(value, sf, parent)
+			i.initNode(&node{
+				value:    v.Index(k),
+				strField: elemField,
+				parent:   parent,
+				owner:    parent.owner,
+				index:    k,
+			})
 		}
 	case reflect.Map:
-		for _, key := range v.MapKeys() {
-			value := v.MapIndex(key)
-			i.initValue(value, sf, parent)
+		keys := v.MapKeys()
+		sortKeys(keys)
+		for _, key := range keys {
+			if keyField != nil {
+				i.initNode(&node{
+					value:    key,
+					strField: keyField,
+					parent:   parent,
+					owner:    parent.owner,
+					key:      key,
+				})
+			}
+			i.initNode(&node{
+				value:    v.MapIndex(key),
+				strField: elemField,
+				parent:   parent,
+				owner:    parent.owner,
+				key:      key,
+			})
 		}
 	}
 }
 
-func newIterator(value interface{}) Iterator {
+func newIterator(value interface{}, unexported Unexported) *iterator {
 	v := reflect.ValueOf(value)
 	if value == nil || isNil(v) {
-		return (*iterator)(nil)
+		return nil
 	}
-	result := &iterator{}
-	result.initValue(v, nil, nil)
+	result := &iterator{unexported: unexported}
+	result.initValue(v, nil, nil, nil)
 	return result
 }
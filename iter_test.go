@@ -68,34 +68,34 @@ func TestIter(t *testing.T) {
 		ID    int
 	}
 
-	iter := newIterator(nil)
+	iter := newIterator(nil, UnexportedSkip)
 	assert.Nil(t, iter)
 	assert.Implements(t, (*Iterator)(nil), iter)
 
 	type testType struct{}
-	iter = newIterator(&testType{})
+	iter = newIterator(&testType{}, UnexportedSkip)
 	checkItemsHelper(t, iter, []string{"testType"})
 
 	//single value string
-	iter = newIterator("test")
+	iter = newIterator("test", UnexportedSkip)
 	assert.NotNil(t, iter)
 	checkItemsHelper(t, iter, []string{"string"})
 
 	//single value int ptr
 	i := 123
-	iter = newIterator(&i)
+	iter = newIterator(&i, UnexportedSkip)
 	assert.NotNil(t, iter)
 	checkItemsHelper(t, iter, []string{"int"})
 
 	//slice
 	arr := []byte{1, 2}
-	iter = newIterator(arr)
+	iter = newIterator(arr, UnexportedSkip)
 	checkItemsHelper(t, iter, []string{"slice", "uint8", "uint8"})
 
 	//struct
-	iter = newIterator(testStruct{Field: "str", ID: 1})
+	iter = newIterator(testStruct{Field: "str", ID: 1}, UnexportedSkip)
 	checkItemsHelper(t, iter, []string{"testStruct", "Field", "ID"})
-	iter = newIterator(&testStruct{})
+	iter = newIterator(&testStruct{}, UnexportedSkip)
 	checkItemsHelper(t, iter, []string{"testStruct", "Field", "ID"})
 
 	//struct field
@@ -103,7 +103,7 @@ func TestIter(t *testing.T) {
 		ID int
 		F1 testStruct
 	}
-	iter = newIterator(testNestedStruct{ID: 10, F1: testStruct{Field: "str", ID: 1}})
+	iter = newIterator(testNestedStruct{ID: 10, F1: testStruct{Field: "str", ID: 1}}, UnexportedSkip)
 	checkItemsHelper(t, iter, []string{"testNestedStruct", "ID",
 		"F1", "Field", "ID"})
 
@@ -112,20 +112,20 @@ func TestIter(t *testing.T) {
 		testStruct
 		NewID int
 	}
-	iter = newIterator(testEmbeddedStruct{testStruct: testStruct{Field: "str", ID: 1}, NewID: 123})
+	iter = newIterator(testEmbeddedStruct{testStruct: testStruct{Field: "str", ID: 1}, NewID: 123}, UnexportedSkip)
 	checkItemsHelper(t, iter, []string{"testEmbeddedStruct", "testStruct", "Field", "ID",
 		"NewID"})
 
 	//slice of struct
 	arrayStructs := []testStruct{{Field: "str", ID: 1}}
-	iter = newIterator(arrayStructs)
+	iter = newIterator(arrayStructs, UnexportedSkip)
 	checkItemsHelper(t, iter, []string{"slice", "testStruct", "Field", "ID"})
 
 	//map of struct
 	mStructs := map[string]testStruct{
 		"one": {Field: "str", ID: 1},
 	}
-	iter = newIterator(mStructs)
+	iter = newIterator(mStructs, UnexportedSkip)
 	checkItemsHelper(t, iter, []string{"map", "testStruct", "Field", "ID"})
 
 	//slice&map in struct
@@ -139,8 +139,94 @@ func TestIter(t *testing.T) {
 		NewID: 123,
 		Slice: arrayStructs,
 		Map:   mStructs,
-	})
+	}, UnexportedSkip)
 	checkItemsHelper(t, iter, []string{"testSliceStruct", "NewID", "Slice", "testStruct",
 		"Field", "ID", "Map", "testStruct", "Field", "ID"})
 
 }
+
+func TestIterSkipChildren(t *testing.T) {
+	type testStruct struct {
+		Field string
+		ID    int
+	}
+	type testSkip struct {
+		First  testStruct
+		Slice  []testStruct
+		Second testStruct
+	}
+
+	iter := newIterator(&testSkip{Slice: []testStruct{{}}}, UnexportedSkip)
+	iter.skipChildren()
+	names := []string{}
+	for iter.HasNext() {
+		item := iter.Next()
+		names = append(names, item.Name())
+		if item.Name() == "First" || item.Name() == "Slice" {
+			iter.skipChildren()
+		}
+	}
+	assert.Equal(t, []string{"testSkip", "First", "Slice", "Second", "Field", "ID"}, names)
+
+	iter = newIterator(nil, UnexportedSkip)
+	iter.skipChildren()
+	assert.False(t, iter.HasNext())
+}
+
+func TestIterPathAndKey(t *testing.T) {
+	type testItem struct {
+		Name string
+	}
+	type testPath struct {
+		Items []testItem
+		Named map[string]*testItem
+		Tags  map[int]string `check:"dive,keys,required,endkeys"`
+	}
+
+	iter := newIterator(&testPath{
+		Items: []testItem{{}},
+		Named: map[string]*testItem{"b": {}, "a": {}},
+		Tags:  map[int]string{2: "two", 1: "one"},
+	}, UnexportedSkip)
+	paths := []string{}
+	keys := []interface{}{}
+	for iter.HasNext() {
+		item := iter.Next()
+		paths = append(paths, item.Path())
+		if item.Key().IsValid() {
+			keys = append(keys, item.Key().Interface())
+		}
+	}
+	assert.Equal(t, []string{"", "Items", "Items[0]", "Items[0].Name",
+		"Named", "Named[a]", "Named[a].Name", "Named[b]", "Named[b].Name",
+		"Tags", "Tags[1]", "Tags[1]", "Tags[2]", "Tags[2]"}, paths)
+	assert.Equal(t, []interface{}{"a", "b", 1, 1, 2, 2}, keys)
+}
+
+func TestIterInterface(t *testing.T) {
+	type testPlugin struct {
+		Name string
+	}
+	type testPlugins struct {
+		Plugin  interface{}
+		Plugins []interface{}
+		Named   map[string]interface{}
+	}
+
+	iter := newIterator(&testPlugins{
+		Plugin:  testPlugin{},
+		Plugins: []interface{}{nil, &testPlugin{}, "str"},
+		Named:   map[string]interface{}{"one": &testPlugin{}},
+	}, UnexportedSkip)
+	paths := []string{}
+	for iter.HasNext() {
+		paths = append(paths, iter.Next().Path())
+	}
+	assert.Equal(t, []string{"", "Plugin", "Plugin.Name", "Plugins", "Plugins[0]", "Plugins[1]",
+		"Plugins[1].Name", "Plugins[2]", "Named", "Named[one]", "Named[one].Name"}, paths)
+
+	var plugin interface{} = &testPlugin{}
+	assert.Equal(t, reflect.Struct, unwrap(reflect.ValueOf(&plugin).Elem()).Kind())
+	assert.Equal(t, reflect.Ptr, elem(reflect.ValueOf(&plugin).Elem()).Kind())
+	assert.True(t, canIterate(reflect.ValueOf(&plugin).Elem()))
+}
@@ -0,0 +1,413 @@
+package checks
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+//Normalizer is implemented by values normalizing themselves before checks,
+//e.g. a pointer to a struct trimming its fields
+type Normalizer interface {
+	Normalize()
+}
+
+var (
+	normalizerInterface = reflect.TypeOf((*Normalizer)(nil)).Elem()
+
+	normalizableMu    sync.RWMutex
+	normalizableTypes = map[reflect.Type]bool{}
+)
+
+//WithNormalizedCopy checks the normalized copy of the value leaving the value
+//passed to Check unchanged. Only values changed by normalization are copied, other
+//values are shared with the original. By default settable values are normalized in place
+func WithNormalizedCopy() Option {
+	return func(c *SimpeChecker) {
+		c.normalizedCopy = true
+	}
+}
+
+//normalizes reports whether the rule normalizes the value
+func (r rule) normalizes() bool {
+	switch r.name {
+	case ruleTrim, ruleLower, ruleUpper, ruleCollapse:
+		return r.name == r.text
+	}
+	return false
+}
+
+//stringType reports whether the type can be normalized by rules
+func stringType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.String
+}
+
+//normalizeString applies the normalization rule to s
+func normalizeString(s string, name string) string {
+	switch name {
+	case ruleTrim:
+		return strings.TrimSpace(s)
+	case ruleLower:
+		return strings.ToLower(s)
+	case ruleUpper:
+		return strings.ToUpper(s)
+	case ruleCollapse:
+		return collapse(s)
+	}
+	return s
+}
+
+//collapse replaces each run of white space with a single space
+func collapse(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			if !space {
+				b.WriteByte(' ')
+			}
+			space = true
+			continue
+		}
+		space = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+//normalize normalizes the value before checks. Values passed by pointer are
+//normalized in place unless the checker normalizes a copy
+func (c *SimpeChecker) normalize(v interface{}) interface{} {
+	value := reflect.ValueOf(v)
+	if v == nil || isNil(value) {
+		return v
+	}
+	if c.normalizedCopy {
+		//values held by interfaces are normalized by checks, they are copied too
+		value, _ = normalizedCopy(value, false, false, c.unexported)
+	}
+	if !normalizable(value.Type()) {
+		return value.Interface()
+	}
+	if value.Kind() != reflect.Ptr {
+		//the value passed by value is normalized in its copy
+		value = addressable(value)
+	}
+	normalizeValue(value, nil, c.unexported)
+	return value.Interface()
+}
+
+//normalizeValue calls Normalize of the value and applies normalization rules of the field
+//to settable values, then normalizes nested values. Elements of maps are normalized in their
+//copies set back to the map. Values held by interfaces are normalized by the iterator
+//when checks reach them, see normalizeHeld
+func normalizeValue(v reflect.Value, rules []rule, unexported Unexported) {
+	//values held by pointers and interfaces are normalized by the nested call
+	if v.Kind() != reflect.Ptr && v.Kind() != reflect.Interface {
+		if v.CanAddr() && v.Addr().Type().Implements(normalizerInterface) {
+			v.Addr().Interface().(Normalizer).Normalize()
+		} else if v.Type().Implements(normalizerInterface) {
+			v.Interface().(Normalizer).Normalize()
+		}
+	}
+	if len(rules) > 0 {
+		if s := reflect.Indirect(v); s.Kind() == reflect.String && s.CanSet() {
+			text := s.String()
+			for _, r := range rules {
+				text = normalizeString(text, r.name)
+			}
+			s.SetString(text)
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			normalizeValue(v.Elem(), nil, unexported)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for k := 0; k < v.NumField(); k++ {
+			sf := t.Field(k)
+			if !exported(sf) && unexported != UnexportedCheck {
+				continue
+			}
+			if !v.Field(k).CanInterface() && !v.CanAddr() {
+				continue
+			}
+			value, elem := fieldRules(sf)
+			if len(value) == 0 && len(elem) == 0 && !normalizable(sf.Type) {
+				continue
+			}
+			field := accessible(v, k)
+			normalizeValue(field, value, unexported)
+			if len(elem) > 0 {
+				normalizeElems(field, elem, unexported)
+			}
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if normalizable(v.Type().Elem()) {
+			normalizeElems(v, nil, unexported)
+		}
+	}
+}
+
+//normalizeElems applies normalization rules of the dive to elements of the slice,
+//array or map
+func normalizeElems(v reflect.Value, rules []rule, unexported Unexported) {
+	v = reflect.Indirect(v)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for k := 0; k < v.Len(); k++ {
+			normalizeValue(v.Index(k), rules, unexported)
+		}
+	case reflect.Map:
+		if !v.CanInterface() {
+			return
+		}
+		for _, key := range v.MapKeys() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			normalizeValue(elem, rules, unexported)
+			v.SetMapIndex(key, elem)
+		}
+	}
+}
+
+//normalizeHeld normalizes the value held by the interface. Types of such values
+//are not known before checks, so normalizable does not look into interfaces
+func normalizeHeld(v reflect.Value, unexported Unexported) {
+	if v.Kind() != reflect.Interface || v.IsNil() {
+		return
+	}
+	if elem := v.Elem(); normalizable(elem.Type()) {
+		normalizeValue(elem, nil, unexported)
+	}
+}
+
+//fieldRules returns normalization rules of the field and of its elements
+func fieldRules(sf reflect.StructField) ([]rule, []rule) {
+	sTag, ok := sf.Tag.Lookup("check")
+	if !ok {
+		return nil, nil
+	}
+	rules, _, elem, _, _ := splitDive(parseTag(sTag))
+	return normalizations(rules), normalizations(elem)
+}
+
+func normalizations(rules []rule) []rule {
+	var result []rule
+	for _, r := range rules {
+		if r.normalizes() {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+//normalizable reports whether values of the type may be changed by normalization:
+//the type implements Normalizer or has fields with normalization rules. Values held
+//by interfaces are not known and are normalized when checks reach them
+func normalizable(t reflect.Type) bool {
+	normalizableMu.RLock()
+	result, ok := normalizableTypes[t]
+	normalizableMu.RUnlock()
+	if ok {
+		return result
+	}
+	result = hasNormalization(t, map[reflect.Type]bool{})
+	normalizableMu.Lock()
+	normalizableTypes[t] = result
+	normalizableMu.Unlock()
+	return result
+}
+
+func hasNormalization(t reflect.Type, visited map[reflect.Type]bool) bool {
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+	if t.Implements(normalizerInterface) || reflect.PtrTo(t).Implements(normalizerInterface) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return hasNormalization(t.Elem(), visited)
+	case reflect.Struct:
+		for k := 0; k < t.NumField(); k++ {
+			sf := t.Field(k)
+			if value, elem := fieldRules(sf); len(value) > 0 || len(elem) > 0 {
+				return true
+			}
+			if hasNormalization(sf.Type, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type copyKey struct {
+	t          reflect.Type
+	unexported Unexported
+}
+
+var (
+	copyMu    sync.RWMutex
+	copyTypes = map[copyKey]bool{}
+)
+
+//mayNormalize reports whether values of the type may hold values changed by normalization:
+//normalizable values or interfaces whose values are normalized when checks reach them
+func mayNormalize(t reflect.Type, unexported Unexported) bool {
+	key := copyKey{t: t, unexported: unexported}
+	copyMu.RLock()
+	result, ok := copyTypes[key]
+	copyMu.RUnlock()
+	if ok {
+		return result
+	}
+	result = holdsNormalization(t, unexported, map[reflect.Type]bool{})
+	copyMu.Lock()
+	copyTypes[key] = result
+	copyMu.Unlock()
+	return result
+}
+
+func holdsNormalization(t reflect.Type, unexported Unexported, visited map[reflect.Type]bool) bool {
+	if normalizable(t) {
+		return true
+	}
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return holdsNormalization(t.Elem(), unexported, visited)
+	case reflect.Struct:
+		for k := 0; k < t.NumField(); k++ {
+			sf := t.Field(k)
+			if !exported(sf) && unexported != UnexportedCheck {
+				continue
+			}
+			if holdsNormalization(sf.Type, unexported, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//normalizedCopy returns the copy of the value for normalization. Only values changed by
+//normalization and values holding them are copied, other values are shared with the
+//original. rules and elem report whether the value and its elements have normalization
+//rules. Reports whether the value is copied
+func normalizedCopy(v reflect.Value, rules, elem bool, unexported Unexported) (reflect.Value, bool) {
+	if !rules && !elem && !mayNormalize(v.Type(), unexported) {
+		return v, false
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v, false
+		}
+		value, ok := normalizedCopy(v.Elem(), rules, elem, unexported)
+		if !ok {
+			return v, false
+		}
+		ptr := reflect.New(v.Type().Elem())
+		ptr.Elem().Set(value)
+		return ptr, true
+	case reflect.Interface:
+		if v.IsNil() {
+			return v, false
+		}
+		value, ok := normalizedCopy(v.Elem(), false, false, unexported)
+		if !ok {
+			return v, false
+		}
+		result := reflect.New(v.Type()).Elem()
+		result.Set(value)
+		return result, true
+	case reflect.Struct:
+		result := reflect.New(v.Type()).Elem()
+		result.Set(v)
+		copied := normalizable(v.Type())
+		t := v.Type()
+		for k := 0; k < v.NumField(); k++ {
+			sf := t.Field(k)
+			if !exported(sf) && unexported != UnexportedCheck {
+				continue
+			}
+			value, elem := fieldRules(sf)
+			field := accessible(result, k)
+			if c, ok := normalizedCopy(field, len(value) > 0, len(elem) > 0, unexported); ok {
+				field.Set(c)
+				copied = true
+			}
+		}
+		if !copied {
+			return v, false
+		}
+		return result, true
+	case reflect.Slice, reflect.Array:
+		var result reflect.Value
+		for k := 0; k < v.Len(); k++ {
+			value, ok := normalizedCopy(v.Index(k), elem, false, unexported)
+			if !ok {
+				continue
+			}
+			if !result.IsValid() {
+				result = copyElems(v)
+			}
+			result.Index(k).Set(value)
+		}
+		if !result.IsValid() {
+			return v, false
+		}
+		return result, true
+	case reflect.Map:
+		if v.IsNil() || !v.CanInterface() {
+			return v, false
+		}
+		var result reflect.Value
+		for _, key := range v.MapKeys() {
+			value, ok := normalizedCopy(v.MapIndex(key), elem, false, unexported)
+			if !ok {
+				continue
+			}
+			if !result.IsValid() {
+				result = reflect.MakeMapWithSize(v.Type(), v.Len())
+				for _, key := range v.MapKeys() {
+					result.SetMapIndex(key, v.MapIndex(key))
+				}
+			}
+			result.SetMapIndex(key, value)
+		}
+		if !result.IsValid() {
+			return v, false
+		}
+		return result, true
+	}
+	//strings with rules are changed, values of other types are shared
+	return v, rules || normalizable(v.Type())
+}
+
+//copyElems returns the shallow copy of the slice or the array
+func copyElems(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Array {
+		result := reflect.New(v.Type()).Elem()
+		reflect.Copy(result, v)
+		return result
+	}
+	result := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+	reflect.Copy(result, v)
+	return result
+}
@@ -0,0 +1,215 @@
+package checks
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testNormalizedItem struct {
+	Name string
+}
+
+//Normalize implements Normalizer
+func (i *testNormalizedItem) Normalize() {
+	i.Name = strings.TrimSpace(i.Name)
+}
+
+type testNormalized struct {
+	LogLevel string             `check:"trim,lower,expect:info;debug;error"`
+	Title    *string            `check:"collapse,upper"`
+	Tags     []string           `check:"dive,trim,required"`
+	Named    map[string]string  `check:"dive,trim"`
+	Item     testNormalizedItem `check:"required"`
+	Items    []*testNormalizedItem
+	secret   string `check:"trim"`
+}
+
+func TestNormalizeString(t *testing.T) {
+	assert.Equal(t, "a  b", normalizeString(" a  b\t", ruleTrim))
+	assert.Equal(t, "ab", normalizeString("AB", ruleLower))
+	assert.Equal(t, "AB", normalizeString("ab", ruleUpper))
+	assert.Equal(t, " a b ", normalizeString(" a \t\n b  ", ruleCollapse))
+	assert.Equal(t, "x", normalizeString("x", ruleRequired))
+
+	assert.True(t, parseRule("trim").normalizes())
+	assert.True(t, parseRule("trim").known())
+	assert.False(t, parseRule("trim:yes").known())
+	assert.False(t, parseRule("required").normalizes())
+}
+
+func TestNormalize(t *testing.T) {
+	title := " a \t title "
+	v := &testNormalized{
+		LogLevel: " Debug ",
+		Title:    &title,
+		Tags:     []string{" a ", "  "},
+		Named:    map[string]string{"one": " x "},
+		Item:     testNormalizedItem{Name: " item "},
+		Items:    []*testNormalizedItem{nil, {Name: " b "}},
+		secret:   " secret ",
+	}
+	errs := New(ModeAll, ErrorAll).Check(v)
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "value required: Tags[1]")
+	assert.Equal(t, "debug", v.LogLevel)
+	assert.Equal(t, " A TITLE ", title)
+	assert.Equal(t, []string{"a", ""}, v.Tags)
+	assert.Equal(t, "x", v.Named["one"])
+	assert.Equal(t, "item", v.Item.Name)
+	assert.Equal(t, "b", v.Items[1].Name)
+	assert.Equal(t, " secret ", v.secret)
+
+	New(ModeAll, ErrorAll, WithUnexported(UnexportedCheck)).Check(v)
+	assert.Equal(t, "secret", v.secret)
+
+	//values passed by value are normalized in the copy
+	value := testNormalized{LogLevel: " INFO ", Item: testNormalizedItem{Name: " "}}
+	errs = New(ModeAll, ErrorAll).Check(value)
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "value required: Item")
+	assert.Equal(t, " INFO ", value.LogLevel)
+}
+
+func TestNormalizedCopy(t *testing.T) {
+	title := " title "
+	v := &testNormalized{
+		LogLevel: " Debug ",
+		Title:    &title,
+		Tags:     []string{" a "},
+		Named:    map[string]string{"one": " x "},
+		Items:    []*testNormalizedItem{{Name: " b "}},
+	}
+	errs := New(ModeAll, ErrorAll, WithNormalizedCopy()).Check(v)
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "value required: Item")
+	assert.Equal(t, " Debug ", v.LogLevel)
+	assert.Equal(t, " title ", title)
+	assert.Equal(t, []string{" a "}, v.Tags)
+	assert.Equal(t, " x ", v.Named["one"])
+	assert.Equal(t, " b ", v.Items[0].Name)
+
+	type testPlain struct {
+		Plugin interface{}
+		Name   string `check:"required"`
+	}
+	item := &testNormalizedItem{Name: " b "}
+	assert.Empty(t, New(ModeAll, ErrorAll, WithNormalizedCopy()).Check(&testPlain{Plugin: item, Name: "x"}))
+	assert.Equal(t, " b ", item.Name)
+}
+
+func TestNormalizeBadSyntax(t *testing.T) {
+	type testBadNormalize struct {
+		Port int `check:"trim"`
+	}
+	errs := New(ModeAll, ErrorAll).Check(&testBadNormalize{})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "bad syntax: Port trim")
+	assert.EqualError(t, Compile(reflect.TypeOf(testBadNormalize{})),
+		"invalid check tags of checks.testBadNormalize:\n\tbad syntax: Port trim")
+}
+
+func TestNormalizable(t *testing.T) {
+	type plain struct {
+		Name string `check:"required"`
+		Tags []string
+	}
+	type nested struct {
+		Plain plain
+		Items []testNormalizedItem
+	}
+	assert.False(t, normalizable(reflect.TypeOf(plain{})))
+	assert.True(t, normalizable(reflect.TypeOf(&nested{})))
+	assert.True(t, normalizable(reflect.TypeOf(testNormalized{})))
+	//values held by interfaces are normalized when checks reach them
+	assert.False(t, normalizable(reflect.TypeOf([]interface{}{})))
+	assert.True(t, normalizable(reflect.TypeOf(map[string]testNormalizedItem{})))
+}
+
+func TestNormalizeHeld(t *testing.T) {
+	type testHeld struct {
+		Plugin  interface{}
+		Plugins map[string]interface{}
+		Names   map[string]string `check:"dive,trim,lower,expect:a;b"`
+	}
+	assert.True(t, normalizable(reflect.TypeOf(testHeld{})))
+	item := &testNormalizedItem{Name: " a "}
+	v := &testHeld{
+		Plugin:  item,
+		Plugins: map[string]interface{}{"one": map[string]testNormalizedItem{"x": {Name: " x "}}},
+		Names:   map[string]string{"one": " A ", "two": "c"},
+	}
+	errs := New(ModeAll, ErrorAll).Check(v)
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "unexpected value: Names[two] c")
+	assert.Equal(t, "a", item.Name)
+	assert.Equal(t, map[string]testNormalizedItem{"x": {Name: "x"}}, v.Plugins["one"])
+	assert.Equal(t, map[string]string{"one": "a", "two": "c"}, v.Names)
+
+	type testPlain struct {
+		Plugin interface{}
+		Name   string `check:"required"`
+	}
+	assert.False(t, normalizable(reflect.TypeOf(testPlain{})))
+	item = &testNormalizedItem{Name: " b "}
+	assert.NoError(t, Check(&testPlain{Plugin: item, Name: "x"}))
+	assert.Equal(t, "b", item.Name)
+}
+
+func TestCopyNormalized(t *testing.T) {
+	type testShared struct {
+		Data []int
+	}
+	type testCopied struct {
+		testNormalized
+		Mu     *sync.Mutex
+		Shared *testShared
+		Plain  []string
+		Held   interface{}
+		Static interface{}
+	}
+	title := "title"
+	mu := &sync.Mutex{}
+	shared := &testShared{Data: []int{1}}
+	item := &testNormalizedItem{Name: "c"}
+	v := &testCopied{
+		testNormalized: testNormalized{
+			Title:  &title,
+			Tags:   []string{"a"},
+			Named:  map[string]string{"one": "x"},
+			Items:  []*testNormalizedItem{{Name: "b"}},
+			secret: "secret",
+		},
+		Mu:     mu,
+		Shared: shared,
+		Plain:  []string{"p"},
+		Held:   item,
+		Static: shared,
+	}
+	value, ok := normalizedCopy(reflect.ValueOf(v), false, false, UnexportedSkip)
+	assert.True(t, ok)
+	c := value.Interface().(*testCopied)
+	assert.Equal(t, v, c)
+	*c.Title = "changed"
+	c.Tags[0] = "changed"
+	c.Named["one"] = "changed"
+	c.Items[0].Name = "changed"
+	c.Held.(*testNormalizedItem).Name = "changed"
+	assert.Equal(t, "title", title)
+	assert.Equal(t, []string{"a"}, v.Tags)
+	assert.Equal(t, "x", v.Named["one"])
+	assert.Equal(t, "b", v.Items[0].Name)
+	assert.Equal(t, "c", item.Name)
+	assert.True(t, c.Mu == mu)
+	assert.True(t, c.Shared == shared)
+	assert.True(t, c.Static.(*testShared) == shared)
+	assert.True(t, &v.Plain[0] == &c.Plain[0])
+
+	plain := &testShared{Data: []int{1}}
+	value, ok = normalizedCopy(reflect.ValueOf(plain), false, false, UnexportedSkip)
+	assert.False(t, ok)
+	assert.True(t, value.Interface().(*testShared) == plain)
+}
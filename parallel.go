@@ -0,0 +1,154 @@
+package checks
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+//parallelBatch is the number of values checked by a worker at once
+const parallelBatch = 64
+
+//WithParallel enables checking of values by the pool of workers.
+//The order of the results is the same as in the serial checking
+func WithParallel(workers int) Option {
+	return func(c *SimpeChecker) {
+		c.workers = workers
+	}
+}
+
+//checkParallel checks values of the iterator by the pool of workers. Results
+//are added to the collector in order, workers stop when the collector is done.
+//Nested values are checked after their parent, nested values of the skipped value
+//are not checked. Panics of checks are raised again when workers stop
+func (c *SimpeChecker) checkParallel(iter *iterator, collector *Collector) {
+	var nodes []*node
+	if iter != nil {
+		nodes = iter.nodes
+	}
+
+	batches := (len(nodes) + parallelBatch - 1) / parallelBatch
+	results := make([][]error, len(nodes))
+	parents := parentIndexes(nodes)
+	pruned := make([]bool, len(nodes))
+	done := make([]chan struct{}, batches)
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	var (
+		next    int64 = -1
+		stop    int32
+		wg      sync.WaitGroup
+		failure *workerPanic
+		once    sync.Once
+	)
+	check := func(batch int) {
+		defer close(done[batch])
+		defer func() {
+			//panics of checks are raised again by the goroutine of the checker
+			if r := recover(); r != nil {
+				once.Do(func() {
+					failure = &workerPanic{value: r}
+				})
+				atomic.StoreInt32(&stop, 1)
+			}
+		}()
+		first, last := batchBounds(batch, len(nodes))
+		for i := first; i < last; i++ {
+			if p := parents[i]; p >= 0 {
+				if p < first {
+					<-done[p/parallelBatch]
+				}
+				if pruned[p] || skipped(results[p]) {
+					pruned[i] = true
+					continue
+				}
+			}
+			if atomic.LoadInt32(&stop) != 0 {
+				return
+			}
+			results[i] = withPath(c.checkValue(collector.ctx, nodes[i], nodes[i].owner), nodes[i].Path)
+		}
+	}
+	worker := func() {
+		defer wg.Done()
+		for atomic.LoadInt32(&stop) == 0 {
+			batch := int(atomic.AddInt64(&next, 1))
+			if batch >= batches {
+				return
+			}
+			check(batch)
+		}
+	}
+
+	workers := c.workers
+	if workers > batches {
+		workers = batches
+	}
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go worker()
+	}
+
+	skipTo := 0
+loop:
+	for batch := range done {
+		<-done[batch]
+		if atomic.LoadInt32(&stop) != 0 {
+			break
+		}
+		first, last := batchBounds(batch, len(nodes))
+		if skipTo > first {
+			first = skipTo
+		}
+		for i := first; i < last; i++ {
+			if !collector.Add(results[i]...) {
+				break loop
+			}
+			if collector.Skipped() {
+				skipTo = nodes[i].end
+				i = skipTo - 1
+			}
+		}
+	}
+	atomic.StoreInt32(&stop, 1)
+	wg.Wait()
+	if failure != nil {
+		panic(failure.value)
+	}
+}
+
+//workerPanic holds the value of the panic recovered by the worker
+type workerPanic struct {
+	value interface{}
+}
+
+//parentIndexes returns indexes of parents of nodes, -1 for the root
+func parentIndexes(nodes []*node) []int {
+	result := make([]int, len(nodes))
+	var open []int
+	for i := range nodes {
+		for len(open) > 0 && nodes[open[len(open)-1]].end <= i {
+			open = open[:len(open)-1]
+		}
+		result[i] = -1
+		if len(open) > 0 {
+			result[i] = open[len(open)-1]
+		}
+		open = append(open, i)
+	}
+	return result
+}
+
+//skipped reports whether results of the value skip its nested values
+func skipped(errs []error) bool {
+	return len(errs) > 0 && errs[0] == ErrSkip
+}
+
+func batchBounds(batch int, n int) (int, int) {
+	last := (batch + 1) * parallelBatch
+	if last > n {
+		last = n
+	}
+	return batch * parallelBatch, last
+}
@@ -0,0 +1,191 @@
+package checks
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testParallelItem struct {
+	ID    int
+	Name  string `check:"required"`
+	calls *int64
+}
+
+func (i testParallelItem) Check() error {
+	if i.calls != nil {
+		atomic.AddInt64(i.calls, 1)
+	}
+	if i.ID%1000 == 999 {
+		return fmt.Errorf("item %d", i.ID)
+	}
+	if i.ID == -1 {
+		return ErrSkip
+	}
+	return nil
+}
+
+type testParallel struct {
+	Items []testParallelItem
+	Map   map[string]testParallelItem
+}
+
+func newTestParallel(n int, calls *int64) *testParallel {
+	v := &testParallel{
+		Map: map[string]testParallelItem{
+			"one": {ID: 999, calls: calls},
+		},
+	}
+	for i := 0; i < n; i++ {
+		item := testParallelItem{ID: i, Name: "name", calls: calls}
+		if i%700 == 0 {
+			item.Name = ""
+		}
+		v.Items = append(v.Items, item)
+	}
+	return v
+}
+
+func TestCheckParallel(t *testing.T) {
+	v := newTestParallel(10000, nil)
+	for _, mode := range []Mode{ModeFirst, ModeAll} {
+		want := New(mode, ErrorAll).Check(v)
+		got := New(mode, ErrorAll, WithParallel(4)).Check(v)
+		assert.Equal(t, want, got)
+	}
+
+	errs := New(ModeAll, ErrorAll, WithParallel(8)).Check(v)
+	assert.Len(t, errs, 27)
+	assert.EqualError(t, errs[0], "value required: Items[0].Name")
+	assert.EqualError(t, errs[2], "item 999")
+
+	assert.Nil(t, New(ModeAll, ErrorAll, WithParallel(4)).Check(nil))
+	assert.Nil(t, New(ModeAll, ErrorAll, WithParallel(4)).Check(&testParallel{}))
+}
+
+func TestCheckParallelStop(t *testing.T) {
+	var calls int64
+	v := newTestParallel(100000, &calls)
+	errs := New(ModeFirst, ErrorAll, WithParallel(2)).Check(v)
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "value required: Items[0].Name")
+	assert.True(t, atomic.LoadInt64(&calls) < 100000)
+
+	v.Items[500].ID = -1
+	errs = New(ModeAll, ErrorAll, WithParallel(4), WithSkipAll()).Check(v)
+	assert.Nil(t, errs)
+
+	v.Items[700].ID = -1
+	v.Items[1400].ID = -1
+	want := New(ModeAll, ErrorAll).Check(v)
+	errs = New(ModeAll, ErrorAll, WithParallel(4)).Check(v)
+	assert.Equal(t, want, errs)
+	v.Items[700].ID = 700
+	v.Items[1400].ID = 1400
+
+	v.Items[500].ID = 500
+	v.Items[0].Name = "name"
+	v.Items[1].Name = ""
+	errs = New(ModeFirst, ErrorType, WithParallel(4)).Check(v)
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "value required: Items[1].Name")
+}
+
+type testParallelChild struct {
+	Port *int
+}
+
+func (c testParallelChild) Check() error {
+	if *c.Port == 0 {
+		return ErrValueRequired
+	}
+	return nil
+}
+
+type testParallelParent struct {
+	Skip     bool
+	Children []testParallelChild
+}
+
+func (p testParallelParent) Check() error {
+	if p.Skip {
+		return ErrSkip
+	}
+	return nil
+}
+
+func TestCheckParallelSkipChildren(t *testing.T) {
+	v := &struct {
+		Parents []testParallelParent
+	}{}
+	port := 0
+	for i := 0; i < 300; i++ {
+		p := testParallelParent{Skip: i%3 == 0}
+		for k := 0; k < 50; k++ {
+			child := testParallelChild{Port: &port}
+			if p.Skip {
+				//children of skipped values must not be checked
+				child.Port = nil
+			}
+			p.Children = append(p.Children, child)
+		}
+		v.Parents = append(v.Parents, p)
+	}
+	want := New(ModeAll, ErrorAll).Check(v)
+	assert.Len(t, want, 200*50)
+	for _, workers := range []int{2, 4, 8} {
+		assert.Equal(t, want, New(ModeAll, ErrorAll, WithParallel(workers)).Check(v))
+	}
+}
+
+type testParallelPanic struct {
+	ID int
+}
+
+func (p testParallelPanic) Check() error {
+	if p.ID == 500 {
+		panic("check 500")
+	}
+	return nil
+}
+
+func TestCheckParallelPanic(t *testing.T) {
+	v := &struct {
+		Items []testParallelPanic
+	}{}
+	for i := 0; i < 1000; i++ {
+		v.Items = append(v.Items, testParallelPanic{ID: i})
+	}
+	assert.PanicsWithValue(t, "check 500", func() {
+		New(ModeAll, ErrorAll).Check(v)
+	})
+	assert.PanicsWithValue(t, "check 500", func() {
+		New(ModeAll, ErrorAll, WithParallel(4)).Check(v)
+	})
+}
+
+func TestParentIndexes(t *testing.T) {
+	v := struct {
+		A []int
+		B struct{ C, D int }
+	}{A: []int{1, 2}}
+	assert.Equal(t, []int{-1, 0, 1, 1, 0, 4, 4}, parentIndexes(newIterator(v, UnexportedSkip).nodes))
+}
+
+func BenchmarkCheckSerial(b *testing.B) {
+	v := newTestParallel(10000, nil)
+	c := New(ModeAll, ErrorAll)
+	for i := 0; i < b.N; i++ {
+		c.Check(v)
+	}
+}
+
+func BenchmarkCheckParallel(b *testing.B) {
+	v := newTestParallel(10000, nil)
+	c := New(ModeAll, ErrorAll, WithParallel(4))
+	for i := 0; i < b.N; i++ {
+		c.Check(v)
+	}
+}
@@ -0,0 +1,165 @@
+package checks
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//Kinds of the path segments
+const (
+	segmentField = iota
+	segmentIndex
+	segmentKey
+)
+
+//segment is the part of the path of the nested value
+type segment struct {
+	kind  int
+	name  string
+	index int
+	key   interface{}
+}
+
+//EnterField enters the field of the struct. Errors added before the call
+//of Leave get the path of the field
+func (c *Collector) EnterField(name string) {
+	c.path = append(c.path, segment{kind: segmentField, name: name})
+}
+
+//EnterIndex enters the element of the slice or the array
+func (c *Collector) EnterIndex(i int) {
+	c.path = append(c.path, segment{kind: segmentIndex, index: i})
+}
+
+//EnterKey enters the element of the map
+func (c *Collector) EnterKey(key interface{}) {
+	c.path = append(c.path, segment{kind: segmentKey, key: key})
+}
+
+//Leave leaves the value entered last
+func (c *Collector) Leave() {
+	c.path = c.path[:len(c.path)-1]
+}
+
+//Path returns the path of the value entered last
+func (c *Collector) Path() string {
+	var b strings.Builder
+	for _, s := range c.path {
+		switch s.kind {
+		case segmentField:
+			writeField(&b, s.name)
+		case segmentIndex:
+			writeIndex(&b, s.index)
+		case segmentKey:
+			writeKey(&b, s.key)
+		}
+	}
+	return b.String()
+}
+
+func writeField(b *strings.Builder, name string) {
+	if b.Len() > 0 {
+		b.WriteByte('.')
+	}
+	b.WriteString(name)
+}
+
+func writeIndex(b *strings.Builder, i int) {
+	b.WriteByte('[')
+	b.WriteString(strconv.Itoa(i))
+	b.WriteByte(']')
+}
+
+func writeKey(b *strings.Builder, key interface{}) {
+	fmt.Fprintf(b, "[%v]", key)
+}
+
+//joinPath returns the path of the nested value
+func joinPath(prefix string, path string) string {
+	if prefix == "" || path == "" || path[0] == '[' {
+		return prefix + path
+	}
+	return prefix + "." + path
+}
+
+//withPath sets the path of check results without the path. Results of nested
+//values, e.g. duplicate elements, get the path of the nested value
+func withPath(errs []error, path func() string) []error {
+	p := ""
+	for i, e := range errs {
+		if are, ok := e.(ErrorCheckResult); ok && are.Path == "" {
+			if p == "" {
+				p = path()
+			}
+			are.Path = joinPath(p, are.subpath)
+			errs[i] = are
+		}
+	}
+	return errs
+}
+
+//Less reports whether the map key a sorts before the map key b.
+//Keys are checked in the sorted order
+func Less(a, b interface{}) bool {
+	return lessValue(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func sortKeys(keys []reflect.Value) {
+	sort.Slice(keys, func(i, j int) bool {
+		return lessValue(keys[i], keys[j])
+	})
+}
+
+func lessValue(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return !a.IsValid() && b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return a.Type().String() < b.Type().String()
+	}
+	switch a.Kind() {
+	case reflect.String:
+		return a.String() < b.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		if real(a.Complex()) != real(b.Complex()) {
+			return real(a.Complex()) < real(b.Complex())
+		}
+		return imag(a.Complex()) < imag(b.Complex())
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	case reflect.Ptr, reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() < b.Pointer()
+	case reflect.Interface:
+		return lessValue(a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if lessValue(a.Field(i), b.Field(i)) {
+				return true
+			}
+			if lessValue(b.Field(i), a.Field(i)) {
+				return false
+			}
+		}
+		return false
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if lessValue(a.Index(i), b.Index(i)) {
+				return true
+			}
+			if lessValue(b.Index(i), a.Index(i)) {
+				return false
+			}
+		}
+		return false
+	}
+	return fmt.Sprintf("%v", a) < fmt.Sprintf("%v", b)
+}
@@ -0,0 +1,106 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectorPath(t *testing.T) {
+	c := New(ModeAll, ErrorAll).newCollector(context.Background())
+	assert.Equal(t, "", c.Path())
+
+	c.EnterField("Backends")
+	c.EnterIndex(1)
+	c.EnterField("Named")
+	c.EnterKey("one")
+	assert.Equal(t, "Backends[1].Named[one]", c.Path())
+
+	custom := errors.New("custom")
+	c.Add(newError(ErrValueRequired, "Named", nil, ErrorType), custom)
+	c.Leave()
+	c.Leave()
+	assert.Equal(t, "Backends[1]", c.Path())
+	c.Leave()
+	c.Leave()
+	c.Add(newError(ErrValueRequired, "Listen", nil, ErrorType))
+
+	errs := c.Result()
+	assert.Len(t, errs, 3)
+	assert.EqualError(t, errs[0], "value required: Backends[1].Named[one]")
+	assert.Equal(t, "Named", errs[0].(ErrorCheckResult).FieldName)
+	assert.Equal(t, custom, errs[1])
+	assert.EqualError(t, errs[2], "value required: Listen")
+}
+
+func TestWithPath(t *testing.T) {
+	calls := 0
+	path := func() string {
+		calls++
+		return "Items[0].Name"
+	}
+	errs := withPath([]error{
+		newError(ErrValueRequired, "Name", nil, ErrorType),
+		ErrorCheckResult{FieldName: "Name", Path: "Other"},
+		errors.New("custom"),
+		newError(ErrDeprecated, "Name", nil, WarningType),
+	}, path)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "Items[0].Name", errs[0].(ErrorCheckResult).Path)
+	assert.Equal(t, "Other", errs[1].(ErrorCheckResult).Path)
+	assert.Equal(t, "Items[0].Name", errs[3].(ErrorCheckResult).Path)
+
+	assert.Nil(t, withPath(nil, path))
+	assert.Equal(t, 1, calls)
+}
+
+func TestLess(t *testing.T) {
+	type testKey struct {
+		A int
+		B string
+	}
+	assert.True(t, Less("a", "b"))
+	assert.False(t, Less("b", "a"))
+	assert.True(t, Less(-1, 2))
+	assert.True(t, Less(uint(1), uint(2)))
+	assert.True(t, Less(1.5, 2.5))
+	assert.True(t, Less(complex(1, 1), complex(1, 2)))
+	assert.True(t, Less(false, true))
+	assert.True(t, Less(testKey{1, "b"}, testKey{2, "a"}))
+	assert.True(t, Less(testKey{1, "a"}, testKey{1, "b"}))
+	assert.False(t, Less(testKey{1, "a"}, testKey{1, "a"}))
+	assert.True(t, Less([2]int{1, 2}, [2]int{1, 3}))
+	assert.True(t, Less(nil, 1))
+	assert.True(t, Less(1, "a"))
+
+	keys := reflect.ValueOf(map[interface{}]int{"b": 1, 2: 2, "a": 3, 1: 4}).MapKeys()
+	sortKeys(keys)
+	sorted := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, key.Interface())
+	}
+	assert.Equal(t, []interface{}{1, 2, "a", "b"}, sorted)
+}
+
+func TestCheckMapPaths(t *testing.T) {
+	type testBackend struct {
+		Address string `check:"required"`
+	}
+	type testMap struct {
+		Backends map[string]testBackend
+	}
+	errs := CheckAll(testMap{Backends: map[string]testBackend{"z": {}, "a": {}, "m": {Address: "m"}}})
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "value required: Backends[a].Address")
+	assert.EqualError(t, errs[1], "value required: Backends[z].Address")
+}
+
+func TestJoinPath(t *testing.T) {
+	assert.Equal(t, "Name", joinPath("", "Name"))
+	assert.Equal(t, "Plugin", joinPath("Plugin", ""))
+	assert.Equal(t, "Plugin.Name", joinPath("Plugin", "Name"))
+	assert.Equal(t, "Plugins[0][1]", joinPath("Plugins[0]", "[1]"))
+}
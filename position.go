@@ -0,0 +1,286 @@
+package checks
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type (
+	//Position is the position in the source. Offset starts at 0, Line and Column
+	//start at 1, Column counts bytes
+	Position struct {
+		Offset int
+		Line   int
+		Column int
+	}
+
+	//Positions holds positions of values by their paths, e.g. Servers[0].Host.
+	//Fields and map elements have positions of their keys, slice and array elements
+	//have positions of their values, the root value has the path ""
+	Positions map[string]Position
+
+	//jsonField is the field of the struct decoded from the key of the JSON object
+	jsonField struct {
+		key  string
+		name string //the path segment of the field, empty for fields of embedded structs
+		typ  reflect.Type
+	}
+
+	//positionScanner scans the valid JSON document recording positions of values
+	//of the type decoded
+	positionScanner struct {
+		data      []byte
+		pos       int
+		lines     []int //offsets of the line starts
+		positions Positions
+	}
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+//DecodeJSON decodes the JSON document into v like json.Unmarshal and returns positions
+//of keys of fields and map elements and of slice and array elements by their paths
+func DecodeJSON(data []byte, v interface{}) (Positions, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	s := &positionScanner{data: data, positions: make(Positions), lines: []int{0}}
+	for i, b := range data {
+		if b == '\n' {
+			s.lines = append(s.lines, i+1)
+		}
+	}
+	s.skipSpace()
+	s.positions[""] = s.position(s.pos)
+	s.value(reflect.TypeOf(v), "")
+	return s.positions, nil
+}
+
+//Lookup returns the position of the path or of the nearest parent of the path having
+//the position, e.g. the position of the object missing the required field
+func (p Positions) Lookup(path string) (Position, bool) {
+	for {
+		if pos, ok := p[path]; ok {
+			return pos, true
+		}
+		if path == "" {
+			return Position{}, false
+		}
+		path = parentPath(path)
+	}
+}
+
+//Attach sets positions of check results by their paths
+func (p Positions) Attach(errs []error) []error {
+	for i, e := range errs {
+		if are, ok := e.(ErrorCheckResult); ok && are.Position == nil {
+			if pos, ok := p.Lookup(are.name()); ok {
+				are.Position = &pos
+				errs[i] = are
+			}
+		}
+	}
+	return errs
+}
+
+//parentPath returns the path without the last field, index or key
+func parentPath(path string) string {
+	names := splitPath(path)
+	if len(names) == 0 {
+		return ""
+	}
+	last := names[len(names)-1]
+	parent := strings.TrimSuffix(path, last)
+	return strings.TrimSuffix(parent, ".")
+}
+
+func (s *positionScanner) position(offset int) Position {
+	line := sort.Search(len(s.lines), func(i int) bool { return s.lines[i] > offset })
+	return Position{Offset: offset, Line: line, Column: offset - s.lines[line-1] + 1}
+}
+
+func (s *positionScanner) skipSpace() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\r', '\n':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+//value scans the value decoded into the type t, t is nil if paths of the nested
+//values are unknown
+func (s *positionScanner) value(t reflect.Type, path string) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t != nil && (reflect.PtrTo(t).Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(textUnmarshalerType)) {
+		t = nil
+	}
+	s.skipSpace()
+	switch s.data[s.pos] {
+	case '{':
+		s.object(t, path)
+	case '[':
+		s.array(t, path)
+	case '"':
+		s.str()
+	default:
+		for s.pos < len(s.data) && strings.IndexByte(",]} \t\r\n", s.data[s.pos]) < 0 {
+			s.pos++
+		}
+	}
+}
+
+func (s *positionScanner) object(t reflect.Type, path string) {
+	var fields []jsonField
+	if t != nil && t.Kind() == reflect.Struct {
+		fields = jsonFields(t)
+	}
+	s.pos++
+	for {
+		s.skipSpace()
+		if s.data[s.pos] == '}' {
+			s.pos++
+			return
+		}
+		if s.data[s.pos] == ',' {
+			s.pos++
+			s.skipSpace()
+		}
+		start := s.pos
+		key := s.str()
+		s.skipSpace()
+		s.pos++ //colon
+		var (
+			child reflect.Type
+			p     string
+			known bool
+		)
+		if t != nil {
+			switch t.Kind() {
+			case reflect.Struct:
+				if f, ok := lookupJSONField(fields, key); ok {
+					child, p, known = f.typ, joinPath(path, f.name), true
+				}
+			case reflect.Map:
+				var b strings.Builder
+				b.WriteString(path)
+				writeKey(&b, key)
+				child, p, known = t.Elem(), b.String(), true
+			}
+		}
+		if known && p != path {
+			s.positions[p] = s.position(start)
+		}
+		s.value(child, p)
+	}
+}
+
+func (s *positionScanner) array(t reflect.Type, path string) {
+	var elem reflect.Type
+	if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		elem = t.Elem()
+	}
+	s.pos++
+	for i := 0; ; i++ {
+		s.skipSpace()
+		if s.data[s.pos] == ']' {
+			s.pos++
+			return
+		}
+		if s.data[s.pos] == ',' {
+			s.pos++
+			s.skipSpace()
+		}
+		var b strings.Builder
+		b.WriteString(path)
+		writeIndex(&b, i)
+		if elem != nil {
+			s.positions[b.String()] = s.position(s.pos)
+		}
+		s.value(elem, b.String())
+	}
+}
+
+//str scans the string and returns its value
+func (s *positionScanner) str() string {
+	start := s.pos
+	s.pos++
+	for s.data[s.pos] != '"' {
+		if s.data[s.pos] == '\\' {
+			s.pos++
+		}
+		s.pos++
+	}
+	s.pos++
+	var text string
+	json.Unmarshal(s.data[start:s.pos], &text)
+	return text
+}
+
+//jsonFields returns fields of the struct decoded from keys of JSON objects.
+//Fields of embedded structs without the name in the json tag are promoted and follow
+//the fields of the struct, so the fields of the struct hide them
+func jsonFields(t reflect.Type) []jsonField {
+	var result, embedded []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := sf.Name
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if comma := strings.IndexByte(tag, ','); comma >= 0 {
+			tag = tag[:comma]
+		}
+		if promoted(sf) {
+			name = ""
+			if tag == "" {
+				ft := sf.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				embedded = append(embedded, jsonFields(ft)...)
+				continue
+			}
+		} else if sf.PkgPath != "" {
+			continue
+		}
+		key := sf.Name
+		if tag != "" {
+			key = tag
+		}
+		result = append(result, jsonField{key: key, name: name, typ: sf.Type})
+	}
+	return append(result, embedded...)
+}
+
+//lookupJSONField returns the field of the key, the exact match is preferred
+//to the case-insensitive one like in encoding/json
+func lookupJSONField(fields []jsonField, key string) (jsonField, bool) {
+	for _, f := range fields {
+		if f.key == key {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.key, key) {
+			return f, true
+		}
+	}
+	return jsonField{}, false
+}
@@ -0,0 +1,146 @@
+package checks
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type positionBase struct {
+	ID   string `check:"required"`
+	Name string `json:"title"`
+}
+
+type positionServer struct {
+	Host string `json:"host" check:"required"`
+	Port int    `json:"port"`
+}
+
+type positionConfig struct {
+	positionBase
+	Name     string `json:"name" check:"required"`
+	Listen   string
+	Timeout  time.Duration
+	Started  time.Time `json:"started"`
+	Servers  []positionServer
+	Labels   map[string]string `json:"labels" check:"dive,required"`
+	Extra    interface{}       `json:"extra"`
+	Ignored  string            `json:"-"`
+	internal string
+}
+
+const positionJSON = `{
+  "ID": "",
+  "title": "base",
+  "name": "",
+  "listen": ":80",
+  "started": "2020-01-01T00:00:00Z",
+  "extra": {"Name": "x", "list": [1, 2]},
+  "escaped\/key": "\"}]",
+  "Servers": [
+    {"host": "a", "port": 80},
+    {"port": 81}
+  ],
+  "labels": {"a.b": "", "c": "d"}
+}`
+
+func TestDecodeJSON(t *testing.T) {
+	var cfg positionConfig
+	positions, err := DecodeJSON([]byte(positionJSON), &cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, ":80", cfg.Listen)
+	assert.Equal(t, 81, cfg.Servers[1].Port)
+
+	at := func(key string) Position {
+		offset := strings.Index(positionJSON, key)
+		line := strings.Count(positionJSON[:offset], "\n") + 1
+		column := offset - strings.LastIndex(positionJSON[:offset], "\n")
+		return Position{Offset: offset, Line: line, Column: column}
+	}
+	assert.Equal(t, Positions{
+		"":                at("{"),
+		"ID":              at(`"ID"`),
+		"Name":            at(`"name"`),
+		"Listen":          at(`"listen"`),
+		"Started":         at(`"started"`),
+		"Extra":           at(`"extra"`),
+		"Servers":         at(`"Servers"`),
+		"Servers[0]":      at(`{"host": "a"`),
+		"Servers[0].Host": at(`"host"`),
+		"Servers[0].Port": at(`"port"`),
+		"Servers[1]":      at(`{"port": 81}`),
+		"Servers[1].Port": at(`"port": 81`),
+		"Labels":          at(`"labels"`),
+		"Labels[a.b]":     at(`"a.b"`),
+		"Labels[c]":       at(`"c"`),
+	}, positions)
+	assert.Equal(t, Position{Offset: 4, Line: 2, Column: 3}, positions["ID"])
+
+	_, err = DecodeJSON([]byte(`{"Listen": 1}`), &cfg)
+	assert.Error(t, err)
+}
+
+func TestPositionsAttach(t *testing.T) {
+	var cfg positionConfig
+	positions, err := DecodeJSON([]byte(positionJSON), &cfg)
+	if !assert.NoError(t, err) {
+		return
+	}
+	errs := positions.Attach(New(ModeAll, ErrorAll).Check(&cfg))
+	var lines []string
+	for _, e := range errs {
+		are := e.(ErrorCheckResult)
+		if assert.NotNil(t, are.Position, are.Path) {
+			lines = append(lines, are.Path+" "+are.Position.String())
+		}
+	}
+	assert.Equal(t, []string{
+		"ID 2:3",
+		"Name 4:3",
+		"Servers[1].Host 11:5",
+		"Labels[a.b] 13:14",
+	}, lines)
+
+	var b bytes.Buffer
+	assert.NoError(t, NewReport(errs[:1]).WriteTree(&b))
+	assert.Equal(t, "ID\n└── error: value required at 2:3\n1 error, 0 warnings\n", b.String())
+
+	b.Reset()
+	assert.NoError(t, NewReport(errs[:1]).WriteSARIF(&b, ExportFile("config.json")))
+	assert.Contains(t, strings.Join(strings.Fields(b.String()), ""), `"region":{"startLine":2,"startColumn":3}`)
+}
+
+func TestPositionsLookup(t *testing.T) {
+	positions := Positions{
+		"":           {Offset: 0, Line: 1, Column: 1},
+		"Servers[1]": {Offset: 10, Line: 2, Column: 5},
+	}
+	for path, want := range map[string]Position{
+		"Servers[1].Host": positions["Servers[1]"],
+		"Servers[1]":      positions["Servers[1]"],
+		"Servers[0].Host": positions[""],
+		"Labels[a.b]":     positions[""],
+	} {
+		got, ok := positions.Lookup(path)
+		assert.True(t, ok, path)
+		assert.Equal(t, want, got, path)
+	}
+	_, ok := Positions{}.Lookup("Servers")
+	assert.False(t, ok)
+}
+
+func TestParentPath(t *testing.T) {
+	for path, want := range map[string]string{
+		"":                "",
+		"Listen":          "",
+		"Log.Level":       "Log",
+		"Servers[0]":      "Servers",
+		"Servers[0].Host": "Servers[0]",
+		"Labels[a.b]":     "Labels",
+	} {
+		assert.Equal(t, want, parentPath(path), path)
+	}
+}
@@ -0,0 +1,174 @@
+package checks
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	//Report is the result of checks of the value
+	Report struct {
+		results []error
+	}
+
+	//Group is the results of checks having the same key, e.g. the path of the field
+	Group struct {
+		Key     string
+		Results []error
+	}
+)
+
+//NewReport returns the report of the results of checks
+func NewReport(errs []error) *Report {
+	return &Report{results: errs}
+}
+
+//Report checks the value and returns the report of the results
+func (c *SimpeChecker) Report(v interface{}) *Report {
+	return NewReport(c.Check(v))
+}
+
+//typeOf returns the type of the result. Errors other than check results are errors
+func typeOf(e error) Type {
+	if are, ok := e.(ErrorCheckResult); ok {
+		return are.GetType()
+	}
+	return ErrorType
+}
+
+//Results returns all results of checks
+func (r *Report) Results() []error {
+	return r.results
+}
+
+//Errors returns results of the error type and errors other than check results
+func (r *Report) Errors() []error {
+	return r.filter(ErrorType)
+}
+
+//Warnings returns results of the warning type
+func (r *Report) Warnings() []error {
+	return r.filter(WarningType)
+}
+
+func (r *Report) filter(typ Type) []error {
+	var result []error
+	for _, e := range r.results {
+		if typeOf(e)&typ != 0 {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+//HasErrors reports whether the report has results of the error type
+func (r *Report) HasErrors() bool {
+	return r.Count(ErrorType) > 0
+}
+
+//Count returns the number of results of the type, Count(ErrorAll) returns the number of all results
+func (r *Report) Count(typ Type) int {
+	n := 0
+	for _, e := range r.results {
+		if typeOf(e)&typ != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+//ByPath groups results by the path of the field in the order of the first result of the path.
+//Errors other than check results have the empty path
+func (r *Report) ByPath() []Group {
+	return r.group(func(e error) string {
+		if are, ok := e.(ErrorCheckResult); ok {
+			return are.name()
+		}
+		return ""
+	})
+}
+
+//ByRule groups results by the cause, e.g. value required, in the order of the first result
+//of the cause
+func (r *Report) ByRule() []Group {
+	return r.group(func(e error) string {
+		if are, ok := e.(ErrorCheckResult); ok {
+			return are.Cause().Error()
+		}
+		return e.Error()
+	})
+}
+
+func (r *Report) group(key func(e error) string) []Group {
+	var result []Group
+	index := make(map[string]int)
+	for _, e := range r.results {
+		k := key(e)
+		i, ok := index[k]
+		if !ok {
+			i = len(result)
+			index[k] = i
+			result = append(result, Group{Key: k})
+		}
+		result[i].Results = append(result[i].Results, e)
+	}
+	return result
+}
+
+//Summary returns the numbers of errors and warnings, e.g. 2 errors, 1 warning
+func (r *Report) Summary() string {
+	return fmt.Sprintf("%s, %s", plural(r.Count(ErrorType), "error"), plural(r.Count(WarningType), "warning"))
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return "1 " + word
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
+
+//String returns results grouped by the path, one result per line, and the summary:
+//
+//	Listen
+//	  error: value required
+//	Timeout
+//	  warning: deprecated parameter
+//	1 error, 1 warning
+//
+//Errors other than check results are written before the groups
+func (r *Report) String() string {
+	var b strings.Builder
+	groups := r.ByPath()
+	for _, g := range groups {
+		if g.Key != "" {
+			continue
+		}
+		for _, e := range g.Results {
+			fmt.Fprintf(&b, "%s: %s\n", typeOf(e), message(e))
+		}
+	}
+	for _, g := range groups {
+		if g.Key == "" {
+			continue
+		}
+		b.WriteString(g.Key)
+		b.WriteByte('\n')
+		for _, e := range g.Results {
+			fmt.Fprintf(&b, "  %s: %s\n", typeOf(e), message(e))
+		}
+	}
+	b.WriteString(r.Summary())
+	return b.String()
+}
+
+//message returns the text of the result without the path of the field
+//and with the position of the field if known, e.g. value required at 3:5
+func message(e error) string {
+	if are, ok := e.(ErrorCheckResult); ok {
+		if are.Position != nil {
+			return are.message() + " at " + are.Position.String()
+		}
+		return are.message()
+	}
+	return e.Error()
+}
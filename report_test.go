@@ -0,0 +1,91 @@
+package checks
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport(t *testing.T) {
+	other := errors.New("other")
+	errs := []error{
+		newError(ErrValueRequired, "Listen", nil, ErrorType),
+		newError(ErrDeprecated, "Timeout", nil, WarningType),
+		other,
+		newError(ErrValueRequired, "Log.Level", nil, ErrorType),
+		newError(ErrNoMatch, "Listen", "re:[0-9]+", ErrorType),
+	}
+	r := NewReport(errs)
+	assert.Equal(t, errs, r.Results())
+	assert.Equal(t, []error{errs[0], errs[2], errs[3], errs[4]}, r.Errors())
+	assert.Equal(t, []error{errs[1]}, r.Warnings())
+	assert.True(t, r.HasErrors())
+	assert.Equal(t, 4, r.Count(ErrorType))
+	assert.Equal(t, 1, r.Count(WarningType))
+	assert.Equal(t, 5, r.Count(ErrorAll))
+	assert.Equal(t, "4 errors, 1 warning", r.Summary())
+
+	assert.Equal(t, []Group{
+		{Key: "Listen", Results: []error{errs[0], errs[4]}},
+		{Key: "Timeout", Results: []error{errs[1]}},
+		{Key: "", Results: []error{errs[2]}},
+		{Key: "Log.Level", Results: []error{errs[3]}},
+	}, r.ByPath())
+	assert.Equal(t, []Group{
+		{Key: "value required", Results: []error{errs[0], errs[3]}},
+		{Key: "deprecated parameter", Results: []error{errs[1]}},
+		{Key: "other", Results: []error{errs[2]}},
+		{Key: "no matches", Results: []error{errs[4]}},
+	}, r.ByRule())
+
+	assert.Equal(t, `error: other
+Listen
+  error: value required
+  error: no matches re:[0-9]+
+Timeout
+  warning: deprecated parameter
+Log.Level
+  error: value required
+4 errors, 1 warning`, r.String())
+}
+
+func TestReportEmpty(t *testing.T) {
+	r := NewReport(nil)
+	assert.False(t, r.HasErrors())
+	assert.Nil(t, r.Errors())
+	assert.Nil(t, r.Warnings())
+	assert.Nil(t, r.ByPath())
+	assert.Equal(t, "0 errors, 0 warnings", r.String())
+
+	r = NewReport([]error{newError(ErrDeprecated, "Timeout", nil, WarningType)})
+	assert.False(t, r.HasErrors())
+	assert.Equal(t, "0 errors, 1 warning", r.Summary())
+}
+
+func TestCheckerReport(t *testing.T) {
+	type config struct {
+		Listen  string `check:"required"`
+		Timeout int    `check:"deprecated"`
+		Level   string `check:"expect:debug;info"`
+	}
+	r := New(ModeAll, ErrorAll).Report(&config{Timeout: 1, Level: "warn"})
+	assert.Equal(t, `Listen
+  error: value required
+Timeout
+  warning: deprecated parameter
+Level
+  error: unexpected value warn
+2 errors, 1 warning`, r.String())
+
+	r = New(ModeAll, ErrorAll).Report(&config{Listen: ":80", Level: "info"})
+	assert.False(t, r.HasErrors())
+	assert.Equal(t, "0 errors, 0 warnings", r.String())
+}
+
+func TestTypeString(t *testing.T) {
+	assert.Equal(t, "error", ErrorType.String())
+	assert.Equal(t, "warning", WarningType.String())
+	assert.Equal(t, "all", ErrorAll.String())
+	assert.Equal(t, "Type(8)", Type(8).String())
+}
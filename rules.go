@@ -0,0 +1,187 @@
+package checks
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+//Known rules
+const (
+	ruleRequired   = "required"
+	ruleDeprecated = "deprecated"
+	ruleExpect     = "expect"
+	ruleIExpect    = "iexpect"
+	ruleCall       = "call"
+	ruleRegexp     = "re"
+	ruleNotRegexp  = "!re"
+	ruleMatch      = "match"
+	ruleNotMatch   = "!match"
+	ruleIf         = "if"
+	ruleGroup      = "group"
+	ruleDive       = "dive"
+	ruleKeys       = "keys"
+	ruleEndKeys    = "endkeys"
+	ruleMinLen     = "minlen"
+	ruleTrim       = "trim"
+	ruleLower      = "lower"
+	ruleUpper      = "upper"
+	ruleCollapse   = "collapse"
+	ruleUnique     = "unique"
+)
+
+//rule is the single check of the `check` tag
+type rule struct {
+	name string
+	arg  string
+	text string
+}
+
+var (
+	rulesMu    sync.RWMutex
+	rulesCache = map[string][]rule{}
+
+	regexpMu    sync.RWMutex
+	regexpCache = map[string]*regexp.Regexp{}
+)
+
+func parseRule(text string) rule {
+	idx := strings.Index(text, ":")
+	if idx < 0 {
+		return rule{name: text, text: text}
+	}
+	return rule{
+		name: text[:idx],
+		arg:  text[idx+1:],
+		text: text,
+	}
+}
+
+func parseTag(sTag string) []rule {
+	rulesMu.RLock()
+	rules, ok := rulesCache[sTag]
+	rulesMu.RUnlock()
+	if ok {
+		return rules
+	}
+
+	for _, text := range expandAliases(splitTag(sTag)) {
+		rules = append(rules, parseRule(text))
+	}
+
+	rulesMu.Lock()
+	rulesCache[sTag] = rules
+	rulesMu.Unlock()
+	return rules
+}
+
+//splitTag splits the tag into rules. Commas inside the parentheses of the call rule
+//separate arguments of the method, not rules
+func splitTag(sTag string) []string {
+	var (
+		result []string
+		depth  int
+		start  int
+	)
+	for i := 0; i < len(sTag); i++ {
+		switch sTag[i] {
+		case '(':
+			if strings.HasPrefix(sTag[start:], ruleCall+":") {
+				depth++
+			}
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				result = append(result, sTag[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(result, sTag[start:])
+}
+
+//hasArg reports whether the rule requires an argument
+func (r rule) hasArg() bool {
+	switch r.name {
+	case ruleExpect, ruleIExpect, ruleCall, ruleRegexp, ruleNotRegexp, ruleMatch, ruleNotMatch,
+		ruleIf, ruleGroup, ruleMinLen:
+		return true
+	}
+	return false
+}
+
+//known reports whether the rule is known
+func (r rule) known() bool {
+	switch r.name {
+	case ruleRequired, ruleDeprecated, ruleDive, ruleKeys, ruleEndKeys,
+		ruleTrim, ruleLower, ruleUpper, ruleCollapse:
+		return r.name == r.text
+	case ruleUnique:
+		//the name of the field of elements is optional
+		return true
+	}
+	return r.hasArg()
+}
+
+//matches reports whether the rule matches the value with the regular expression
+func (r rule) matches() bool {
+	switch r.name {
+	case ruleRegexp, ruleNotRegexp, ruleMatch, ruleNotMatch:
+		return true
+	}
+	return false
+}
+
+//negated reports whether the value must not match the regular expression of the rule
+func (r rule) negated() bool {
+	return r.name == ruleNotRegexp || r.name == ruleNotMatch
+}
+
+//regexp returns the compiled regular expression of the rule. Regular expressions of match
+//rules match the entire value, regular expressions of re rules match any part of it.
+//@name refers to the registered regular expression, @@ escapes the leading @ of the expression.
+//Errors are reported for the expression of the tag
+func (r rule) regexp() (*regexp.Regexp, error) {
+	expr := r.arg
+	switch {
+	case strings.HasPrefix(expr, "@@"):
+		expr = expr[1:]
+	case strings.HasPrefix(expr, "@"):
+		var ok bool
+		if expr, ok = lookupPattern(expr[1:]); !ok {
+			return nil, ErrPatternNotFound
+		}
+	}
+	re, err := compileRegexp(expr)
+	if err != nil || (r.name != ruleMatch && r.name != ruleNotMatch) {
+		return re, err
+	}
+	return compileRegexp(anchored(expr))
+}
+
+//anchored returns the regular expression matching the entire text
+func anchored(expr string) string {
+	return "^(?:" + expr + ")$"
+}
+
+func compileRegexp(expr string) (*regexp.Regexp, error) {
+	regexpMu.RLock()
+	re, ok := regexpCache[expr]
+	regexpMu.RUnlock()
+	if ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	regexpMu.Lock()
+	regexpCache[expr] = re
+	regexpMu.Unlock()
+	return re, nil
+}
@@ -0,0 +1,59 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTag(t *testing.T) {
+	rules := parseTag("required,expect:a;b,re:^[a-z]+:[0-9]+$,custom")
+	assert.Equal(t, []rule{
+		{name: "required", text: "required"},
+		{name: "expect", arg: "a;b", text: "expect:a;b"},
+		{name: "re", arg: "^[a-z]+:[0-9]+$", text: "re:^[a-z]+:[0-9]+$"},
+		{name: "custom", text: "custom"},
+	}, rules)
+	assert.True(t, rules[0].known())
+	assert.True(t, rules[1].known())
+	assert.False(t, rules[3].known())
+	assert.False(t, parseRule("required:yes").known())
+	assert.True(t, parseRule("call:").hasArg())
+	assert.False(t, parseRule("deprecated").hasArg())
+
+	assert.Equal(t, rules, parseTag("required,expect:a;b,re:^[a-z]+:[0-9]+$,custom"))
+}
+
+func TestSplitTag(t *testing.T) {
+	assert.Equal(t, []string{"required", "call:Range(1,10)", "expect:a"}, splitTag("required,call:Range(1,10),expect:a"))
+	assert.Equal(t, []string{"re:^(a", "b)$", "call:M(a,b)"}, splitTag("re:^(a,b)$,call:M(a,b)"))
+	assert.Equal(t, []string{"call:M(a,b"}, splitTag("call:M(a,b"))
+	assert.Equal(t, []string{""}, splitTag(""))
+}
+
+func TestCompileRegexp(t *testing.T) {
+	re, err := compileRegexp("[a-z]+")
+	assert.NoError(t, err)
+	cached, err := compileRegexp("[a-z]+")
+	assert.NoError(t, err)
+	assert.True(t, re == cached)
+
+	_, err = compileRegexp("[a-z")
+	assert.Error(t, err)
+}
+
+func TestRuleRegexp(t *testing.T) {
+	re, err := parseRule("match:a|b").regexp()
+	assert.NoError(t, err)
+	assert.Equal(t, "^(?:a|b)$", re.String())
+	re, err = parseRule("!re:a|b").regexp()
+	assert.NoError(t, err)
+	assert.Equal(t, "a|b", re.String())
+	_, err = parseRule("!match:[a-z").regexp()
+	assert.EqualError(t, err, "error parsing regexp: missing closing ]: `[a-z`")
+
+	assert.True(t, parseRule("!re:a").matches())
+	assert.True(t, parseRule("!match:a").negated())
+	assert.False(t, parseRule("match:a").negated())
+	assert.False(t, parseRule("minlen:1").matches())
+}
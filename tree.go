@@ -0,0 +1,192 @@
+package checks
+
+import (
+	"bufio"
+	"io"
+	"os"
+)
+
+//Colors of the tree
+const (
+	colorReset  = "\x1b[0m"
+	colorBold   = "\x1b[1m"
+	colorRed    = "\x1b[31m"
+	colorYellow = "\x1b[33m"
+)
+
+type (
+	//TreeOption configures the tree rendering of the report
+	TreeOption func(t *tree)
+
+	tree struct {
+		color bool
+		w     *bufio.Writer
+	}
+
+	//treeNode is the field or the element of the value having results
+	treeNode struct {
+		name     string
+		results  []error
+		children []*treeNode
+		index    map[string]*treeNode
+	}
+)
+
+//TreeColor enables or disables colors. By default colors are enabled when the writer
+//is the terminal and the NO_COLOR environment variable is not set
+func TreeColor(on bool) TreeOption {
+	return func(t *tree) {
+		t.color = on
+	}
+}
+
+//WriteTree writes results as the tree mirroring the hierarchy of the value and the summary:
+//
+//	Listen
+//	└── error: value required
+//	Servers
+//	└── [0]
+//	    ├── Host
+//	    │   └── error: value required
+//	    └── Timeout
+//	        └── warning: deprecated parameter
+//	2 errors, 1 warning
+//
+//Errors other than check results are written before the tree
+func (r *Report) WriteTree(w io.Writer, opts ...TreeOption) error {
+	t := &tree{
+		color: isTerminal(w) && os.Getenv("NO_COLOR") == "",
+		w:     bufio.NewWriter(w),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	root := newTreeNode("")
+	for _, e := range r.results {
+		n := root
+		if are, ok := e.(ErrorCheckResult); ok {
+			for _, name := range splitPath(are.name()) {
+				n = n.child(name)
+			}
+		}
+		n.results = append(n.results, e)
+	}
+	for _, e := range root.results {
+		t.result(e)
+	}
+	for _, n := range root.children {
+		t.paint(n.name, colorBold)
+		t.w.WriteByte('\n')
+		t.children(n, "")
+	}
+	t.w.WriteString(r.Summary())
+	t.w.WriteByte('\n')
+	return t.w.Flush()
+}
+
+func newTreeNode(name string) *treeNode {
+	return &treeNode{name: name, index: make(map[string]*treeNode)}
+}
+
+//child returns the child node of the name, the node is added if not found
+func (n *treeNode) child(name string) *treeNode {
+	c, ok := n.index[name]
+	if !ok {
+		c = newTreeNode(name)
+		n.index[name] = c
+		n.children = append(n.children, c)
+	}
+	return c
+}
+
+//children writes results and children of the node, results go first
+func (t *tree) children(n *treeNode, prefix string) {
+	count := len(n.results) + len(n.children)
+	for i := 0; i < count; i++ {
+		connector, indent := "├── ", "│   "
+		if i == count-1 {
+			connector, indent = "└── ", "    "
+		}
+		t.w.WriteString(prefix)
+		t.w.WriteString(connector)
+		if i < len(n.results) {
+			t.result(n.results[i])
+			continue
+		}
+		c := n.children[i-len(n.results)]
+		t.paint(c.name, colorBold)
+		t.w.WriteByte('\n')
+		t.children(c, prefix+indent)
+	}
+}
+
+func (t *tree) result(e error) {
+	typ := typeOf(e)
+	color := colorRed
+	if typ == WarningType {
+		color = colorYellow
+	}
+	t.paint(typ.String(), color)
+	t.w.WriteString(": ")
+	t.w.WriteString(message(e))
+	t.w.WriteByte('\n')
+}
+
+func (t *tree) paint(text string, color string) {
+	if t.color {
+		t.w.WriteString(color)
+		t.w.WriteString(text)
+		t.w.WriteString(colorReset)
+		return
+	}
+	t.w.WriteString(text)
+}
+
+//splitPath splits the path into names of fields, indexes and keys,
+//e.g. Servers[0].Host into Servers, [0] and Host
+func splitPath(path string) []string {
+	var (
+		result []string
+		start  int
+		depth  int
+	)
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '[':
+			if depth == 0 && i > start {
+				result = append(result, path[start:i])
+				start = i
+			}
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+			}
+			if depth == 0 {
+				result = append(result, path[start:i+1])
+				start = i + 1
+			}
+		case '.':
+			if depth == 0 {
+				if i > start {
+					result = append(result, path[start:i])
+				}
+				start = i + 1
+			}
+		}
+	}
+	if start < len(path) {
+		result = append(result, path[start:])
+	}
+	return result
+}
+
+//isTerminal reports whether the writer is the character device, e.g. the terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
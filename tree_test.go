@@ -0,0 +1,82 @@
+package checks
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteTree(t *testing.T) {
+	type server struct {
+		Host    string `check:"required"`
+		Timeout int    `check:"deprecated"`
+	}
+	type config struct {
+		Listen  string `check:"required"`
+		Servers []server
+		Labels  map[string]string `check:"dive,required"`
+	}
+	r := New(ModeAll, ErrorAll).Report(&config{
+		Servers: []server{{Host: "a"}, {Timeout: 1}},
+		Labels:  map[string]string{"a.b": ""},
+	})
+	var b bytes.Buffer
+	assert.NoError(t, r.WriteTree(&b))
+	assert.Equal(t, `Listen
+└── error: value required
+Servers
+└── [1]
+    ├── Host
+    │   └── error: value required
+    └── Timeout
+        └── warning: deprecated parameter
+Labels
+└── [a.b]
+    └── error: value required
+3 errors, 1 warning
+`, b.String())
+
+	b.Reset()
+	r = NewReport([]error{
+		newError(ErrValueRequired, "Listen", nil, ErrorType),
+		errors.New("other"),
+		newError(ErrDeprecated, "Timeout", nil, WarningType),
+	})
+	assert.NoError(t, r.WriteTree(&b, TreeColor(true)))
+	assert.Equal(t, "\x1b[31merror\x1b[0m: other\n"+
+		"\x1b[1mListen\x1b[0m\n"+
+		"└── \x1b[31merror\x1b[0m: value required\n"+
+		"\x1b[1mTimeout\x1b[0m\n"+
+		"└── \x1b[33mwarning\x1b[0m: deprecated parameter\n"+
+		"2 errors, 1 warning\n", b.String())
+
+	b.Reset()
+	assert.NoError(t, NewReport(nil).WriteTree(&b))
+	assert.Equal(t, "0 errors, 0 warnings\n", b.String())
+}
+
+func TestSplitPath(t *testing.T) {
+	for path, want := range map[string][]string{
+		"":                 nil,
+		"Listen":           {"Listen"},
+		"Log.Level":        {"Log", "Level"},
+		"Servers[0].Host":  {"Servers", "[0]", "Host"},
+		"Labels[a.b]":      {"Labels", "[a.b]"},
+		"Matrix[1][2]":     {"Matrix", "[1]", "[2]"},
+		"Keys[{1 [2]}].ID": {"Keys", "[{1 [2]}]", "ID"},
+	} {
+		assert.Equal(t, want, splitPath(path), path)
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	assert.False(t, isTerminal(&bytes.Buffer{}))
+	f, err := os.Open("tree_test.go")
+	if assert.NoError(t, err) {
+		defer f.Close()
+		assert.False(t, isTerminal(f))
+	}
+}
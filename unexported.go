@@ -0,0 +1,62 @@
+package checks
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+//Policies of checking unexported fields
+const (
+	//UnexportedSkip skips unexported fields and their nested values
+	UnexportedSkip Unexported = iota
+	//UnexportedCheck checks unexported fields like exported ones
+	UnexportedCheck
+	//UnexportedError reports unexported fields with the `check` tag as errors
+	//and skips other unexported fields
+	UnexportedError
+)
+
+//Unexported is the policy of checking unexported fields.
+//Fields of embedded structs are promoted and checked regardless of the policy
+type Unexported int
+
+//WithUnexported sets the policy of checking unexported fields. Default is UnexportedSkip
+func WithUnexported(p Unexported) Option {
+	return func(c *SimpeChecker) {
+		c.unexported = p
+	}
+}
+
+//promoted reports whether fields of the embedded field are promoted
+func promoted(sf reflect.StructField) bool {
+	t := sf.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return sf.Anonymous && t.Kind() == reflect.Struct
+}
+
+//exported reports whether the field is checked as exported one
+func exported(sf reflect.StructField) bool {
+	return sf.PkgPath == "" || promoted(sf)
+}
+
+//accessible returns the value of the field k of the addressable struct v
+//which can be used without restrictions of unexported fields
+func accessible(v reflect.Value, k int) reflect.Value {
+	field := v.Field(k)
+	if field.CanInterface() {
+		return field
+	}
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+}
+
+//addressable returns the addressable copy of the struct if it is not addressable
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
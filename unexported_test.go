@@ -0,0 +1,74 @@
+package checks
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testBase struct {
+	ID string `check:"required"`
+}
+
+type testInner struct {
+	Value string `check:"required"`
+}
+
+func (i testInner) Check() error {
+	if i.Value == "fail" {
+		return errors.New("inner check")
+	}
+	return nil
+}
+
+type testPromoted struct {
+	testBase
+	*testInner
+	Name   string `check:"required"`
+	secret string `check:"required,expect:a;b"`
+	inner  testInner
+	items  map[string]testInner
+}
+
+func TestPromoted(t *testing.T) {
+	field, _ := reflect.TypeOf(testPromoted{}).FieldByName("testBase")
+	assert.True(t, promoted(field))
+	assert.True(t, exported(field))
+	field, _ = reflect.TypeOf(testPromoted{}).FieldByName("testInner")
+	assert.True(t, promoted(field))
+	field, _ = reflect.TypeOf(testPromoted{}).FieldByName("secret")
+	assert.False(t, promoted(field))
+	assert.False(t, exported(field))
+	field, _ = reflect.TypeOf(testPromoted{}).FieldByName("Name")
+	assert.False(t, promoted(field))
+	assert.True(t, exported(field))
+}
+
+func TestCheckUnexported(t *testing.T) {
+	value := testPromoted{
+		testInner: &testInner{},
+		inner:     testInner{Value: "fail"},
+		items:     map[string]testInner{"one": {}},
+	}
+	errs := CheckAll(value)
+	assert.Len(t, errs, 3)
+	assert.EqualError(t, errs[0], "value required: ID")
+	assert.EqualError(t, errs[1], "value required: Value")
+	assert.EqualError(t, errs[2], "value required: Name")
+
+	errs = New(ModeAll, ErrorType, WithUnexported(UnexportedCheck)).Check(value)
+	assert.Len(t, errs, 7)
+	assert.EqualError(t, errs[3], "value required: secret")
+	assert.EqualError(t, errs[4], "unexpected value: secret ")
+	assert.EqualError(t, errs[5], "inner check")
+	assert.EqualError(t, errs[6], "value required: items[one].Value")
+
+	errs = New(ModeAll, ErrorType, WithUnexported(UnexportedCheck)).Check(&value)
+	assert.Len(t, errs, 7)
+
+	errs = New(ModeAll, ErrorType, WithUnexported(UnexportedError)).Check(&value)
+	assert.Len(t, errs, 4)
+	assert.EqualError(t, errs[3], "unexported field: secret")
+}
@@ -0,0 +1,161 @@
+package checks
+
+import (
+	"reflect"
+	"strings"
+)
+
+//uniqueSyntax checks the unique rule of values of the type: unique elements of slices
+//and arrays or unique:Name fields of elements of slices and arrays of structs.
+//Fields of elements held by interfaces are not known
+func uniqueSyntax(t reflect.Type, r rule) error {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+		return ErrBadSyntax
+	}
+	if r.text == r.name {
+		return nil
+	}
+	if r.arg == "" {
+		return ErrBadSyntax
+	}
+	elem := t.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	switch elem.Kind() {
+	case reflect.Interface:
+		return nil
+	case reflect.Struct:
+		if sf, ok := elem.FieldByName(r.arg); !ok || !exported(sf) {
+			return ErrFieldNotFound
+		}
+		return nil
+	}
+	return ErrBadSyntax
+}
+
+func unique(value reflect.Value, strField *reflect.StructField, r rule) []error {
+	if err := uniqueSyntax(strField.Type, r); err != nil {
+		return []error{newError(err, strField.Name, r.text, ErrorType)}
+	}
+	return duplicates(strField.Name, value, r.arg)
+}
+
+//duplicates returns errors of elements of the slice or the array equal to one of
+//the previous elements, or having the field equal to the field of one of the previous
+//elements. Nil elements and elements without the field or with the field promoted through
+//the nil embedded pointer are not compared.
+//The path of the error is the path of the element or its field
+func duplicates(field string, value reflect.Value, name string) []error {
+	value = reflect.Indirect(value)
+	if !value.IsValid() {
+		return nil
+	}
+	var (
+		result []error
+		seen   = make(map[interface{}]bool)
+		other  []interface{}
+	)
+	for i := 0; i < value.Len(); i++ {
+		item, ok := uniqueKey(value.Index(i), name)
+		if !ok {
+			continue
+		}
+		key := item.Interface()
+		var found bool
+		if hashable(item) {
+			found = seen[key]
+			seen[key] = true
+		} else {
+			for _, o := range other {
+				if reflect.DeepEqual(o, key) {
+					found = true
+					break
+				}
+			}
+			other = append(other, key)
+		}
+		if !found {
+			continue
+		}
+		var b strings.Builder
+		writeIndex(&b, i)
+		if name != "" {
+			writeField(&b, name)
+		}
+		err := newError(ErrDuplicate, field, key, ErrorType)
+		err.subpath = b.String()
+		result = append(result, err)
+	}
+	return result
+}
+
+//hashable reports whether the value can be the key of the map. Values of comparable
+//types holding slices, maps or functions in interfaces are not hashable
+func hashable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface:
+		return v.IsNil() || hashable(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !hashable(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !hashable(v.Index(i)) {
+				return false
+			}
+		}
+		return v.Type().Comparable()
+	}
+	return v.Type().Comparable()
+}
+
+//uniqueKey returns the element or the field of the element compared by the unique rule
+func uniqueKey(v reflect.Value, name string) (reflect.Value, bool) {
+	v = elem(v)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	if name == "" {
+		return v, v.CanInterface()
+	}
+	if v.Kind() != reflect.Struct {
+		return v, false
+	}
+	sf, ok := v.Type().FieldByName(name)
+	if !ok || !exported(sf) {
+		return v, false
+	}
+	for i, index := range sf.Index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			//the field promoted through the nil embedded pointer is not compared
+			if v.IsNil() {
+				return v, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(index)
+	}
+	return uniqueKey(v, "")
+}
+
+//Unique returns errors of duplicate elements of the slice or the array of the unique
+//rule of the tag. The path of the error is the path of the duplicate element
+func Unique(field string, tag string, value interface{}) []error {
+	r := parseRule(tag)
+	v := reflect.ValueOf(value)
+	if err := uniqueSyntax(v.Type(), r); err != nil {
+		return []error{newError(err, field, r.text, ErrorType)}
+	}
+	return duplicates(field, v, r.arg)
+}
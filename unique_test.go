@@ -0,0 +1,117 @@
+package checks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	testListener struct {
+		Name string
+		Port *int
+		tag  string
+	}
+
+	testUnique struct {
+		Names     []string        `check:"unique"`
+		Listeners []*testListener `check:"unique:Name,unique:Port"`
+		Matrix    [][]int         `check:"dive,unique"`
+		Tags      [][]string      `check:"unique"`
+		Any       []interface{}   `check:"unique:Name"`
+	}
+)
+
+func TestUniqueSyntax(t *testing.T) {
+	cases := []struct {
+		value interface{}
+		rule  string
+		want  error
+	}{
+		{[]int{}, "unique", nil},
+		{&[2]int{}, "unique", nil},
+		{[]testListener{}, "unique:Name", nil},
+		{[]*testListener{}, "unique:Port", nil},
+		{[]interface{}{}, "unique:Name", nil},
+		{"", "unique", ErrBadSyntax},
+		{map[string]int{}, "unique", ErrBadSyntax},
+		{[]int{}, "unique:", ErrBadSyntax},
+		{[]int{}, "unique:Name", ErrBadSyntax},
+		{[]testListener{}, "unique:Missing", ErrFieldNotFound},
+		{[]testListener{}, "unique:tag", ErrFieldNotFound},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, uniqueSyntax(reflect.TypeOf(c.value), parseRule(c.rule)), c.rule)
+	}
+	assert.True(t, parseRule("unique:Name").known())
+}
+
+func TestCheckUnique(t *testing.T) {
+	port, other := 80, 80
+	v := &testUnique{
+		Names:     []string{"a", "b", "a", "c", "b"},
+		Listeners: []*testListener{{Name: "web", Port: &port}, nil, {Name: "api"}, {Name: "web", Port: &other}},
+		Matrix:    [][]int{{1, 2}, {3, 3, 3}},
+		Tags:      [][]string{{"a"}, {"b"}, {"a"}},
+		Any:       []interface{}{testListener{Name: "x"}, 1, &testListener{Name: "x"}, nil},
+	}
+	errs := New(ModeAll, ErrorAll).Check(v)
+	assert.Len(t, errs, 8)
+	assert.EqualError(t, errs[0], "duplicate value: Names[2] a")
+	assert.EqualError(t, errs[1], "duplicate value: Names[4] b")
+	assert.EqualError(t, errs[2], "duplicate value: Listeners[3].Name web")
+	assert.EqualError(t, errs[3], "duplicate value: Listeners[3].Port 80")
+	assert.EqualError(t, errs[4], "duplicate value: Matrix[1][1] 3")
+	assert.EqualError(t, errs[5], "duplicate value: Matrix[1][2] 3")
+	assert.EqualError(t, errs[6], "duplicate value: Tags[2] [a]")
+	assert.EqualError(t, errs[7], "duplicate value: Any[2].Name x")
+	assert.Equal(t, "Names[2]", errs[0].(ErrorCheckResult).Path)
+	assert.Equal(t, "Names", errs[0].(ErrorCheckResult).FieldName)
+
+	type testHeld struct {
+		A interface{}
+	}
+	errs = New(ModeAll, ErrorAll).Check(&struct {
+		Held []testHeld `check:"unique"`
+	}{Held: []testHeld{{A: []int{1}}, {A: 1}, {A: []int{1}}, {A: 1}}})
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "duplicate value: Held[2] {[1]}")
+	assert.EqualError(t, errs[1], "duplicate value: Held[3] {1}")
+
+	errs = New(ModeAll, ErrorAll, WithBail()).Check(&testUnique{Names: []string{"a", "a", "a"}})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "duplicate value: Names[1] a")
+}
+
+func TestCheckUniqueNilEmbedded(t *testing.T) {
+	type testItem struct {
+		*testListener
+	}
+	v := &struct {
+		Items []testItem `check:"unique:Name"`
+	}{Items: []testItem{{}, {&testListener{Name: "a"}}, {}, {&testListener{Name: "a"}}}}
+	//elements with the field promoted through nil pointers are not compared
+	errs := New(ModeAll, ErrorAll).Check(v)
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "duplicate value: Items[3].Name a")
+}
+
+func TestUnique(t *testing.T) {
+	errs := Unique("Ports", "unique", &[3]int{1, 2, 1})
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "duplicate value: Ports 1")
+	assert.Equal(t, "[2]", errs[0].(ErrorCheckResult).subpath)
+
+	assert.Nil(t, Unique("Ports", "unique", (*[]int)(nil)))
+	errs = Unique("Port", "unique", 1)
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "bad syntax: Port unique")
+
+	type testBadUnique struct {
+		Port      int            `check:"unique"`
+		Listeners []testListener `check:"unique:Address"`
+	}
+	assert.EqualError(t, Compile(reflect.TypeOf(testBadUnique{})),
+		"invalid check tags of checks.testBadUnique:\n\tbad syntax: Port unique\n\tfield not found: Listeners unique:Address")
+}